@@ -1,32 +1,24 @@
 package dot
 
 import (
-	"fmt"
 	"reflect"
-	"strconv"
 )
 
 // inArray inserts the data into the array at the specified path
-func (d *Dot) inArray(innerObj reflect.Value, currentPath string, parts []string) error {
+func (d *Dot) inArray(op *insertion, innerObj reflect.Value, currentPath trail, parts []segment, content any) error {
 	// Getting the array index
-	index, err := strconv.Atoi(parts[0])
+	index, err := elementIndex(innerObj, parts[0].name, currentPath)
 	if err != nil {
-		return fmt.Errorf(`invalid value "%s" as an array index`, parts[0])
-	}
-
-	// Getting the array index
-	if innerObj.Len() <= index || index < 0 {
-		return fmt.Errorf(
-			"index %d out of range in path %s of type %s",
-			index, currentPath, innerObj.Type(),
-		)
+		return err
 	}
 
-	// Create a variable that matches the type of value
+	// Create a copy of the existing element, so that a nested insert
+	// changes only the specified path and keeps the rest of the element
 	value := reflect.New(innerObj.Type().Elem()).Elem()
+	value.Set(innerObj.Index(index))
 
 	// Recursively insert the value in the path indicated
-	if err = d.insert(value, currentPath, parts[1:], Array); err != nil {
+	if err = d.insert(op, value, currentPath, parts[1:], content, Array); err != nil {
 		return err
 	}
 
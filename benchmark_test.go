@@ -32,6 +32,27 @@ func BenchmarkDotInsert(b *testing.B) {
 	}
 }
 
+func BenchmarkDotInsertPath(b *testing.B) {
+	data := Nested{}
+	data.MapField = make(map[string]Inner)
+	data.SliceField = make([]Inner, 1)
+	obj, _ := dot.New(&data)
+
+	mapPath := dot.MustCompile("MapField.key.Field")
+	slicePath := dot.MustCompile("SliceField.0.Field")
+	structPath := dot.MustCompile("StructField.Field")
+	arrayPath := dot.MustCompile("ArrayField.0.Field")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		obj.InsertPath(mapPath, "My Value")
+		obj.InsertPath(slicePath, "My Value")
+		obj.InsertPath(structPath, "My Value")
+		obj.InsertPath(arrayPath, "My Value")
+	}
+}
+
 func BenchmarkNativeInsert(b *testing.B) {
 	data := Nested{}
 	data.MapField = make(map[string]Inner)
@@ -48,3 +69,23 @@ func BenchmarkNativeInsert(b *testing.B) {
 		data.ArrayField[0].Field = "My Value"
 	}
 }
+
+type Outer struct {
+	Field string
+}
+
+func BenchmarkDotInsertPathTypes(b *testing.B) {
+	inner, outer := Inner{}, Outer{}
+	innerObj, _ := dot.New(&inner)
+	outerObj, _ := dot.New(&outer)
+
+	// The same path is used with the objects of different types in turn
+	path := dot.MustCompile("Field")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		innerObj.InsertPath(path, "My Value")
+		outerObj.InsertPath(path, "My Value")
+	}
+}
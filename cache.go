@@ -0,0 +1,90 @@
+package dot
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// compiledLimit is the maximum number of path strings whose compiled form is kept,
+// so that paths built dynamically, e.g. from user IDs, cannot grow the cache without bound
+const compiledLimit = 1024
+
+// compiledPaths keeps the compiled form of the path strings
+// passed to Insert, Get and Delete, so that they are parsed only once
+var (
+	compiledPaths sync.Map
+	compiledCount atomic.Int32
+)
+
+// compile returns the compiled path, parsing the string only the first time it is met
+func compile(path string) (*Path, error) {
+	if compiled, ok := compiledPaths.Load(path); ok {
+		return compiled.(*Path), nil
+	}
+
+	compiled, err := Compile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The count is checked before it is increased, so that it cannot overflow with the misses past the limit
+	if compiledCount.Load() < compiledLimit && compiledCount.Add(1) <= compiledLimit {
+		compiledPaths.Store(path, compiled)
+	}
+
+	return compiled, nil
+}
+
+// resolved is the segment of a compiled path resolved for a specific type:
+// the index of the struct field or the ready-made map key. Each segment remembers
+// the resolutions for all the types it was used with, so that a path used on the objects
+// of the same shapes walks through struct fields by index and does not parse map keys again
+type resolved struct {
+	typ        reflect.Type  // typ is the struct or map type the segment was resolved for
+	tagKey     string        // tagKey is the TagKey used to find the struct field
+	field      []int         // field is the index of the struct field, nil if there is no such field
+	candidates []string      // candidates are the paths to the fields sharing the name if it is ambiguous
+	key        reflect.Value // key is the map key of the appropriate type
+}
+
+// resolutions are the resolutions of the segment by type. The map is never changed once stored,
+// a new resolution is added to a copy, so that the lookups need no lock
+type resolutions map[reflect.Type]*resolved
+
+// cached returns the resolution of the segment made for the type
+func (s segment) cached(typ reflect.Type) *resolved {
+	if s.cache == nil {
+		return nil
+	}
+
+	if all := s.cache.Load(); all != nil {
+		return (*all)[typ]
+	}
+
+	return nil
+}
+
+// remember stores the resolution of the segment for the type, replacing the previous one for the same type
+func (s segment) remember(result *resolved) {
+	if s.cache == nil {
+		return
+	}
+
+	for {
+		current := s.cache.Load()
+
+		updated := resolutions{}
+		if current != nil {
+			updated = make(resolutions, len(*current)+1)
+			for typ, existing := range *current {
+				updated[typ] = existing
+			}
+		}
+
+		updated[result.typ] = result
+		if s.cache.CompareAndSwap(current, &updated) {
+			return
+		}
+	}
+}
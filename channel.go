@@ -1,30 +1,216 @@
 package dot
 
 import (
+	"context"
 	"reflect"
 )
 
-// inChannel inserts the value into the channel on the specified pat
-func (d *Dot) inChannel(innerObj reflect.Value, currentPath string, parts []string) error {
-	// Create a channel if it does not exist
+// Operations on channels reported by ChannelError
+const (
+	sendOp    = "send"
+	receiveOp = "receive"
+)
+
+// delivery is the value waiting to be sent into the channel
+type delivery struct {
+	channel reflect.Value // channel is the channel in the path
+	value   reflect.Value // value is the value prepared for the channel
+	path    trail         // path is the path to the channel
+}
+
+// inChannel inserts the value into the channel on the specified path,
+// the remaining segments of the path lead inside the value sent
+func (d *Dot) inChannel(op *insertion, innerObj reflect.Value, currentPath trail, parts []segment, content any) error {
+	// Values cannot be sent into a receive-only channel
+	if innerObj.Type().ChanDir()&reflect.SendDir == 0 {
+		return &TypeMismatchError{
+			Path:     currentPath.String(),
+			Expected: innerObj.Type(),
+			Got:      reflect.TypeOf(content),
+			Scenario: Channel,
+		}
+	}
+
+	// Create a channel if it does not exist, a send-only channel is created as bidirectional and converted
 	if innerObj.IsNil() {
-		innerObj.Set(reflect.MakeChan(innerObj.Type(), 1))
+		channelType := reflect.ChanOf(reflect.BothDir, innerObj.Type().Elem())
+		innerObj.Set(reflect.MakeChan(channelType, d.channelBuffer()).Convert(innerObj.Type()))
 	}
 
 	// Create a variable that matches the type of value
 	value := reflect.New(innerObj.Type().Elem()).Elem()
-	if err := d.insert(value, currentPath, parts[1:], Channel); err != nil {
+	if err := d.insert(op, value, currentPath, parts, content, Channel); err != nil {
 		return err
 	}
 
-	// Insert a value in the channel
-	reflect.Select([]reflect.SelectCase{
-		{
-			Dir:  reflect.SelectSend,
-			Chan: innerObj,
-			Send: value,
-		},
-	})
+	// The value is sent into the channel once the object is unlocked,
+	// so that the receivers can use the same Dot in the meantime
+	op.sends = append(op.sends, delivery{channel: innerObj, value: value, path: currentPath})
+
+	return nil
+}
+
+// replacesChannel reports whether the value is the channel, or the pointer to the channel,
+// to be stored in the slot instead of being sent into the channel found there
+func replacesChannel(slot reflect.Type, content any) bool {
+	channelType := slot
+	for channelType.Kind() == reflect.Pointer {
+		channelType = channelType.Elem()
+	}
+
+	contentType := reflect.TypeOf(content)
+	if contentType == nil {
+		return false
+	}
+
+	return contentType.AssignableTo(channelType) ||
+		(contentType.Kind() == reflect.Pointer && contentType.Elem() == channelType)
+}
+
+// deliver sends the values prepared by the insertion into their channels
+func (d *Dot) deliver(op *insertion) error {
+	for _, pending := range op.sends {
+		if err := d.send(op.ctx, pending.channel, pending.value); err != nil {
+			return &ChannelError{Path: pending.path.String(), Type: pending.channel.Type(), Op: sendOp, Err: err}
+		}
+	}
 
 	return nil
 }
+
+// send sends the value into the channel, waiting until the channel is ready to receive it
+// or the context is done, unless the non-blocking mode is enabled
+func (d *Dot) send(ctx context.Context, channel reflect.Value, value reflect.Value) (err error) {
+	// Sending on a closed channel panics, the panic is reported as an error
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recoveredErr, ok := recovered.(error); !ok || recoveredErr.Error() != "send on closed channel" {
+				panic(recovered)
+			}
+
+			err = ErrChannelClosed
+		}
+	}()
+
+	_, _, err = d.wait(ctx, reflect.SelectCase{Dir: reflect.SelectSend, Chan: channel, Send: value}, ErrChannelFull)
+
+	return err
+}
+
+// Receive receives a value from the channel located at the path, waiting until a value is sent
+// unless the non-blocking mode is enabled. As with the receive operator,
+// ok is false and the value is the zero value if the channel is closed
+func (d *Dot) Receive(path string) (value any, ok bool, err error) {
+	return d.ReceiveContext(context.Background(), path)
+}
+
+// ReceiveContext is like Receive, but stops waiting when the context is cancelled or its deadline expires.
+// The object is not locked while waiting, so the value can be inserted into the channel with the same Dot
+func (d *Dot) ReceiveContext(ctx context.Context, path string) (value any, ok bool, err error) {
+	channel, currentPath, err := d.channel(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	received, ok, err := d.wait(ctx, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: channel}, ErrChannelEmpty)
+	if err != nil {
+		return nil, false, &ChannelError{Path: currentPath.String(), Type: channel.Type(), Op: receiveOp, Err: err}
+	}
+
+	return received.Interface(), ok, nil
+}
+
+// Drain receives all the values buffered in the channel located at the path without waiting
+// and returns them as a slice of the element type of the channel, e.g. []int for chan int
+func (d *Dot) Drain(path string) (any, error) {
+	channel, _, err := d.channel(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the values buffered at the moment are received,
+	// so that the values sent in the meantime cannot keep draining forever
+	values := reflect.MakeSlice(reflect.SliceOf(channel.Type().Elem()), 0, channel.Len())
+	for count := channel.Len(); count > 0; count-- {
+		value, ok := channel.TryRecv()
+		if !ok {
+			break
+		}
+
+		values = reflect.Append(values, value)
+	}
+
+	return values.Interface(), nil
+}
+
+// channel finds the channel to receive from at the path
+func (d *Dot) channel(path string) (reflect.Value, trail, error) {
+	compiled, err := compile(path)
+	if err != nil {
+		return reflect.Value{}, trail{}, err
+	}
+
+	// Channels are safe for concurrent use, so the lock is held only while the channel is looked up
+	d.readLock()
+	channel, err := d.get(d.Object, trail{segments: compiled.segments}, compiled.segments)
+	d.readUnlock()
+
+	currentPath := trail{segments: compiled.segments, length: len(compiled.segments)}
+	if err != nil {
+		return reflect.Value{}, currentPath, err
+	}
+
+	for (channel.Kind() == reflect.Interface || channel.Kind() == reflect.Pointer) && !channel.IsNil() {
+		channel = channel.Elem()
+	}
+
+	switch {
+	case channel.Kind() != reflect.Chan || channel.Type().ChanDir()&reflect.RecvDir == 0:
+		return reflect.Value{}, currentPath, &ChannelError{
+			Path: currentPath.String(), Type: channel.Type(), Op: receiveOp, Err: ErrNotChannel,
+		}
+	case channel.IsNil():
+		return reflect.Value{}, currentPath, &ChannelError{
+			Path: currentPath.String(), Type: channel.Type(), Op: receiveOp, Err: ErrNilChannel,
+		}
+	}
+
+	return channel, currentPath, nil
+}
+
+// wait performs the send or receive operation, waiting until the channel is ready or the context is done.
+// In the non-blocking mode it does not wait and returns the busy error
+func (d *Dot) wait(ctx context.Context, operation reflect.SelectCase, busy error) (reflect.Value, bool, error) {
+	// The context that is already done does not let the value through
+	if ctx.Err() != nil {
+		return reflect.Value{}, false, ctx.Err()
+	}
+
+	cases := []reflect.SelectCase{operation}
+
+	switch {
+	case d.NonBlocking:
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	case ctx.Done() != nil:
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	}
+
+	if chosen, value, ok := reflect.Select(cases); chosen == 0 {
+		return value, ok, nil
+	}
+
+	if d.NonBlocking {
+		return reflect.Value{}, false, busy
+	}
+
+	return reflect.Value{}, false, ctx.Err()
+}
+
+// channelBuffer returns the capacity of the channels created in the path
+func (d *Dot) channelBuffer() int {
+	if d.ChannelBuffer < 0 {
+		return 0
+	}
+
+	return d.ChannelBuffer
+}
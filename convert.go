@@ -0,0 +1,162 @@
+package dot
+
+import (
+	"math"
+	"reflect"
+)
+
+// Bounds of the integers that can be exactly represented as floats
+const (
+	maxInt64Float  = float64(1 << 63)
+	maxUint64Float = float64(1 << 64)
+)
+
+// convertValue converts the value to the type in the path, or to the type
+// the pointer in the path refers to. If the value cannot be converted
+// without losing information, it is returned unchanged
+func convertValue(value reflect.Value, target reflect.Type) reflect.Value {
+	if target.Kind() == reflect.Interface || value.Type() == target {
+		return value
+	}
+
+	if target.Kind() == reflect.Pointer && value.Type() != target.Elem() {
+		target = target.Elem()
+	}
+
+	result := reflect.New(target).Elem()
+
+	switch {
+	case value.Kind() == target.Kind() && value.Type().ConvertibleTo(target):
+		// Named types with the same underlying type, e.g. string => type Key string
+		return value.Convert(target)
+	case value.Kind() == reflect.String:
+		// Strings are parsed for primitive types
+		parsed, err := parseType(target.Kind(), value.String())
+		if err != nil {
+			return value
+		}
+
+		if ok := setNumber(result, reflect.ValueOf(parsed)); !ok {
+			return value
+		}
+	default:
+		if ok := setNumber(result, value); !ok {
+			return value
+		}
+	}
+
+	return result
+}
+
+// setNumber sets the number to the result if it fits without overflow and loss of precision.
+// Booleans and complex numbers are set only from values of the same kind
+func setNumber(result reflect.Value, value reflect.Value) bool {
+	switch {
+	case isInt(value.Kind()):
+		return setInt(result, value.Int())
+	case isUint(value.Kind()):
+		return setUint(result, value.Uint())
+	case isFloat(value.Kind()):
+		return setFloat(result, value.Float())
+	case value.Kind() == reflect.Bool && result.Kind() == reflect.Bool:
+		result.SetBool(value.Bool())
+		return true
+	case isComplex(value.Kind()) && isComplex(result.Kind()) && !result.OverflowComplex(value.Complex()):
+		result.SetComplex(value.Complex())
+		return true
+	default:
+		return false
+	}
+}
+
+// setInt sets the signed integer to the number of any kind
+func setInt(result reflect.Value, number int64) bool {
+	switch {
+	case isInt(result.Kind()) && !result.OverflowInt(number):
+		result.SetInt(number)
+	case isUint(result.Kind()) && number >= 0 && !result.OverflowUint(uint64(number)):
+		result.SetUint(uint64(number))
+	case isFloat(result.Kind()):
+		float := roundFloat(result, float64(number))
+		if float >= maxInt64Float || int64(float) != number {
+			return false
+		}
+
+		result.SetFloat(float)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// setUint sets the unsigned integer to the number of any kind
+func setUint(result reflect.Value, number uint64) bool {
+	switch {
+	case isInt(result.Kind()) && number <= math.MaxInt64 && !result.OverflowInt(int64(number)):
+		result.SetInt(int64(number))
+	case isUint(result.Kind()) && !result.OverflowUint(number):
+		result.SetUint(number)
+	case isFloat(result.Kind()):
+		float := roundFloat(result, float64(number))
+		if float >= maxUint64Float || uint64(float) != number {
+			return false
+		}
+
+		result.SetFloat(float)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// setFloat sets the float to the number of any kind, integers accept only whole numbers
+func setFloat(result reflect.Value, number float64) bool {
+	if isFloat(result.Kind()) {
+		if result.OverflowFloat(number) {
+			return false
+		}
+
+		result.SetFloat(number)
+		return true
+	}
+
+	if number != math.Trunc(number) {
+		return false
+	}
+
+	switch {
+	case isInt(result.Kind()) && number >= -maxInt64Float && number < maxInt64Float:
+		return setInt(result, int64(number))
+	case isUint(result.Kind()) && number >= 0 && number < maxUint64Float:
+		return setUint(result, uint64(number))
+	default:
+		return false
+	}
+}
+
+// roundFloat rounds the number to the precision of the float type
+func roundFloat(result reflect.Value, number float64) float64 {
+	if result.Kind() == reflect.Float32 {
+		return float64(float32(number))
+	}
+
+	return number
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isComplex(kind reflect.Kind) bool {
+	return kind == reflect.Complex64 || kind == reflect.Complex128
+}
@@ -0,0 +1,144 @@
+package dot
+
+import "reflect"
+
+// Delete removes the value located at the specified path.
+// Map keys are deleted, slice elements are removed preserving the order of the remaining ones,
+// array elements and struct fields are reset to their zero values
+func (d *Dot) Delete(path string) error {
+	compiled, err := compile(path)
+	if err != nil {
+		return err
+	}
+
+	return d.DeletePath(compiled)
+}
+
+// DeletePath removes the value located at the precompiled path
+func (d *Dot) DeletePath(path *Path) error {
+	// Only one value is deleted at a time, so the path cannot match several values
+	if err := several(path.segments, true); err != nil {
+		return err
+	}
+
+	d.lock()
+	defer d.unlock()
+
+	// An empty path refers to the object itself
+	if len(path.segments) == 0 {
+		resetValue(d.Object)
+		return nil
+	}
+
+	return d.delete(d.Object, trail{segments: path.segments}, path.segments)
+}
+
+// delete is called recursively until the container of the last segment of the path is reached
+func (d *Dot) delete(innerObj reflect.Value, previousPath trail, parts []segment) error {
+	currentPath := previousPath.next(1)
+	last := len(parts) == 1
+
+	// Pointers are followed without allocation, a nil pointer ends up as an unknown path
+	for innerObj.Kind() == reflect.Pointer && !innerObj.IsNil() {
+		innerObj = innerObj.Elem()
+	}
+
+	switch innerObj.Kind() {
+	case reflect.Map:
+		key, err := d.prepareKey(innerObj, parts[0], currentPath)
+		if err != nil {
+			return err
+		}
+
+		value := innerObj.MapIndex(key)
+		if !value.IsValid() {
+			return unknownPath(currentPath)
+		}
+
+		// The zero reflect.Value removes the key from the map
+		if last {
+			innerObj.SetMapIndex(key, reflect.Value{})
+			return nil
+		}
+
+		// Map values cannot be changed in place,
+		// so the value is copied, changed and written back
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+
+		if err := d.delete(copied, currentPath, parts[1:]); err != nil {
+			return err
+		}
+
+		innerObj.SetMapIndex(key, copied)
+		return nil
+	case reflect.Slice:
+		index, err := elementIndex(innerObj, parts[0].name, currentPath)
+		if err != nil {
+			return err
+		}
+
+		if last {
+			removeIndex(innerObj, index)
+			return nil
+		}
+
+		return d.delete(innerObj.Index(index), currentPath, parts[1:])
+	case reflect.Array:
+		index, err := elementIndex(innerObj, parts[0].name, currentPath)
+		if err != nil {
+			return err
+		}
+
+		if last {
+			resetValue(innerObj.Index(index))
+			return nil
+		}
+
+		return d.delete(innerObj.Index(index), currentPath, parts[1:])
+	case reflect.Struct:
+		field, err := d.field(innerObj, parts[0], currentPath, false)
+		if err != nil {
+			return err
+		}
+
+		if last {
+			resetValue(field)
+			return nil
+		}
+
+		return d.delete(field, currentPath, parts[1:])
+	case reflect.Interface:
+		if innerObj.IsNil() {
+			return unknownPath(currentPath)
+		}
+
+		// The value stored in the interface is copied, changed and stored back
+		copied := reflect.New(innerObj.Elem().Type()).Elem()
+		copied.Set(innerObj.Elem())
+
+		if err := d.delete(copied, previousPath, parts); err != nil {
+			return err
+		}
+
+		innerObj.Set(copied)
+		return nil
+	default:
+		return unknownPath(currentPath)
+	}
+}
+
+// removeIndex removes the element of the slice and shifts the following elements to the left
+func removeIndex(innerObj reflect.Value, index int) {
+	length := innerObj.Len()
+	reflect.Copy(innerObj.Slice(index, length), innerObj.Slice(index+1, length))
+
+	// Clearing the last element so that the backing array does not hold a reference to it
+	resetValue(innerObj.Index(length - 1))
+	innerObj.SetLen(length - 1)
+}
+
+// resetValue sets the zero value of its type to the value
+func resetValue(innerObj reflect.Value) {
+	innerObj.Set(reflect.Zero(innerObj.Type()))
+}
@@ -0,0 +1,236 @@
+package dot
+
+import (
+	"errors"
+	"reflect"
+)
+
+// descentToken is the segment of the path matching any number of levels, e.g. **.Password
+const descentToken = "**"
+
+// identity identifies the pointer, the map or the slice met during the recursive descent,
+// so that the values referenced more than once, including cycles, are searched only once
+type identity struct {
+	pointer uintptr
+	typ     reflect.Type
+	length  int
+}
+
+// visits are the values met during the recursive descent
+type visits struct {
+	seen map[identity]bool
+
+	// copies keeps the copies of map values and interface contents alive until the descent ends,
+	// so that their addresses are not reused by the later copies and taken for the visited values
+	copies []reflect.Value
+}
+
+// newVisits prepares the visits of a single recursive descent
+func newVisits() *visits {
+	return &visits{seen: map[identity]bool{}}
+}
+
+// keep keeps the copy alive until the descent ends
+func (v *visits) keep(copied reflect.Value) {
+	v.copies = append(v.copies, copied)
+}
+
+// insertDescent inserts the value along the rest of the path into every value of the tree
+// where the rest of the path begins, e.g. into every field named Password for **.Password
+func (d *Dot) insertDescent(
+	op *insertion, innerObj reflect.Value, previousPath trail, parts []segment, content any, source Scenario,
+) error {
+	failures := d.insertDeep(op, innerObj, previousPath.skip(), parts[1:], content, source, newVisits())
+	if len(failures) > 0 {
+		return &PartialError{Path: previousPath.next(len(parts)).String(), Errors: failures}
+	}
+
+	return nil
+}
+
+// insertDeep searches the value and all the values nested in it. The nested values are changed first,
+// so that the values inserted into the value itself are not searched again
+func (d *Dot) insertDeep(
+	op *insertion, innerObj reflect.Value, currentPath trail, parts []segment, content any, source Scenario,
+	visited *visits,
+) []error {
+	innerObj, ok := follow(innerObj, visited)
+	if !ok {
+		return nil
+	}
+
+	// The value stored in the interface is copied, searched and stored back
+	if innerObj.Kind() == reflect.Interface {
+		copied := reflect.New(innerObj.Elem().Type()).Elem()
+		copied.Set(innerObj.Elem())
+		visited.keep(copied)
+
+		failures := d.insertDeep(op, copied, currentPath, parts, content, source, visited)
+		innerObj.Set(copied)
+
+		return failures
+	}
+
+	var failures []error
+
+	for _, child := range d.children(innerObj) {
+		// Map values cannot be changed in place, so they are copied and written back
+		value := child.value
+		if innerObj.Kind() == reflect.Map {
+			value = reflect.New(child.value.Type()).Elem()
+			value.Set(child.value)
+			visited.keep(value)
+		}
+
+		failures = append(failures, d.insertDeep(op, value, currentPath.descend(child.name), parts, content, source, visited)...)
+
+		if innerObj.Kind() == reflect.Map {
+			innerObj.SetMapIndex(child.key, value)
+		}
+	}
+
+	if !d.begins(innerObj, currentPath, parts) {
+		return failures
+	}
+
+	if err := d.insert(op, innerObj, currentPath, parts, content, source); err != nil {
+		// The failures of the nested wildcards are reported as the failures of the matches
+		var partial *PartialError
+		if errors.As(err, &partial) {
+			return append(failures, partial.Errors...)
+		}
+
+		return append(failures, err)
+	}
+
+	return failures
+}
+
+// getDescent collects the values found along the rest of the path from the value
+// and from all the values nested in it, in the order they are met
+func (d *Dot) getDescent(
+	innerObj reflect.Value, currentPath trail, parts []segment, matches *[]Match, failures *[]error,
+	visited *visits,
+) {
+	innerObj, ok := follow(innerObj, visited)
+	if !ok {
+		return
+	}
+
+	// The value stored in the interface may be a pointer, which is followed the same way
+	if innerObj.Kind() == reflect.Interface {
+		d.getDescent(innerObj.Elem(), currentPath, parts, matches, failures, visited)
+		return
+	}
+
+	if d.begins(innerObj, currentPath, parts) {
+		if err := d.getAll(innerObj, currentPath, parts, matches, failures); err != nil {
+			*failures = append(*failures, err)
+		}
+	}
+
+	for _, child := range d.children(innerObj) {
+		d.getDescent(child.value, currentPath.descend(child.name), parts, matches, failures, visited)
+	}
+}
+
+// begins reports whether the rest of the path begins in the value, i.e. its first segment exists there,
+// or the value is a collection for the wildcard. The fields promoted from the embedded structs
+// are found in the embedded structs themselves, so that every field is matched once
+func (d *Dot) begins(innerObj reflect.Value, currentPath trail, parts []segment) bool {
+	if parts[0].wildcard() {
+		for (innerObj.Kind() == reflect.Interface || innerObj.Kind() == reflect.Pointer) && !innerObj.IsNil() {
+			innerObj = innerObj.Elem()
+		}
+
+		return collection(innerObj.Kind())
+	}
+
+	if innerObj.Kind() == reflect.Struct {
+		if index, _ := d.fieldIndex(innerObj.Type(), parts[0].name); len(index) > 1 {
+			return false
+		}
+	}
+
+	_, err := d.get(innerObj, currentPath, parts[:1])
+	return err == nil
+}
+
+// follow dereferences the pointers and marks the values they lead to as visited.
+// It reports false for nil values and for the values that have already been visited
+func follow(innerObj reflect.Value, visited *visits) (reflect.Value, bool) {
+	for innerObj.Kind() == reflect.Pointer {
+		if innerObj.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		innerObj = innerObj.Elem()
+	}
+
+	var key identity
+
+	switch innerObj.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Interface:
+		if innerObj.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		if innerObj.Kind() == reflect.Interface {
+			return innerObj, true
+		}
+
+		key = identity{pointer: innerObj.Pointer(), typ: innerObj.Type()}
+		if innerObj.Kind() == reflect.Slice {
+			key.length = innerObj.Len()
+		}
+	default:
+		// The values stored in maps and interfaces are copies, they cannot be met twice
+		if !innerObj.CanAddr() {
+			return innerObj, true
+		}
+
+		key = identity{pointer: innerObj.UnsafeAddr(), typ: innerObj.Type()}
+	}
+
+	if visited.seen[key] {
+		return reflect.Value{}, false
+	}
+
+	visited.seen[key] = true
+
+	return innerObj, true
+}
+
+// children returns the values nested in the struct, the map, the slice or the array.
+// Unexported fields are searched only in the unsafe mode, and hidden fields are never searched
+func (d *Dot) children(innerObj reflect.Value) []element {
+	if collection(innerObj.Kind()) {
+		return d.elements(innerObj)
+	}
+
+	if innerObj.Kind() != reflect.Struct {
+		return nil
+	}
+
+	result := make([]element, 0, innerObj.NumField())
+	for index := 0; index < innerObj.NumField(); index++ {
+		field := innerObj.Field(index)
+		structField := innerObj.Type().Field(index)
+
+		if _, hidden := d.tagName(structField); hidden {
+			continue
+		}
+
+		if !field.CanInterface() {
+			if !d.Unsafe || !field.CanAddr() {
+				continue
+			}
+
+			field = exposed(field)
+		}
+
+		result = append(result, element{name: structField.Name, value: field})
+	}
+
+	return result
+}
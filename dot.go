@@ -1,13 +1,18 @@
 package dot
 
 import (
-	"fmt"
+	"context"
 	"reflect"
-	"strings"
+	"sync"
 )
 
-// Repetitive error message
-var errUnknownPath = "unknown path: %s"
+// Repetitive error messages
+var (
+	errUnknownPath = "unknown path: %s"
+	errInterface   = "the type in %s is interface{} and it is impossible to further predict the path"
+	errNotSlice    = "cannot shift the elements in path %s, it does not end with a slice index"
+	errWildcard    = "the path %s can match several values, use GetAll to read them"
+)
 
 // Scenario is a type to define a scenario for
 // further action depending on the type
@@ -20,6 +25,7 @@ const (
 	Map
 	Slice
 	Var
+	Key
 )
 
 // Each scenario has its own error message when the type does not match
@@ -29,67 +35,197 @@ var errMsg = map[Scenario]string{
 	Slice:   "a slice of type %s cannot contain a value of type %s in path %s",
 	Map:     "the map value is of type %s and cannot contain a value of type %s in path %s",
 	Var:     "type %s cannot contain a value of type %s in path %s",
+	Key:     `the map key type is %s you cannot use the placeholder of type %s in path "%s"`,
 }
 
+// defaultGrowLimit is the length up to which slices grow in the grow mode unless GrowLimit is changed
+const defaultGrowLimit = 1024
+
 // Dot provides the necessary methods for manipulating
 // the value from the data types provided
 type Dot struct {
 	Object       reflect.Value  // Object is the reflection interface of the object provided for manipulation
-	Content      any            // Content is the value to be inserted in the specified path
 	Placeholders map[string]any // Placeholders contains substitutes by name for specific map key types
+
+	// Parsers contains functions by type that parse the strings inserted with InsertString
+	Parsers map[reflect.Type]func(string) (any, error)
+
+	// TagKey is the struct tag, e.g. "json", whose names are used to find fields in the path.
+	// The "dot" tag always takes precedence over it, "-" in either of them hides the field
+	TagKey string
+
+	// AutoCreate allows the path to continue through nil interfaces by storing
+	// map[string]any or []any in them, the way encoding/json represents objects and arrays
+	AutoCreate bool
+
+	// ChannelBuffer is the capacity of the channels created in place of nil channels in the path, 1 by default
+	ChannelBuffer int
+
+	// NonBlocking makes sending a value into a channel fail with ErrChannelFull
+	// instead of waiting when the channel is not ready to receive it
+	NonBlocking bool
+
+	// Grow allows writing to the index past the end of a slice by extending the slice
+	// with zero values, as long as the index is less than GrowLimit, 1024 by default
+	Grow      bool
+	GrowLimit int
+
+	// Convert enables the conversion of inserted values to the type in the path: numbers are
+	// converted between types if they fit without overflow and loss of precision, values of named types
+	// are converted to and from their underlying types, and strings are parsed for primitive types
+	Convert bool
+
+	// Unsafe allows the path to read and write unexported struct fields, which is otherwise
+	// reported with UnexportedFieldError. It bypasses the visibility rules of Go and is meant
+	// for preparing test fixtures, not for the data coming from the outside
+	Unsafe bool
+
+	// mu guards the object and the placeholders, it is set only by NewSynchronized
+	mu *sync.RWMutex
 }
 
 // New initialises a new structure with the necessary data for value manipulation
-// The object provided must be a pointer
+// The object provided must be a non-nil pointer
 func New(obj any) (*Dot, error) {
 	innerObj := reflect.ValueOf(obj)
 	if innerObj.Kind() != reflect.Ptr {
-		return nil, fmt.Errorf("expected a pointer")
+		return nil, ErrNotPointer
+	}
+
+	// A nil pointer refers to no value that could be changed
+	if innerObj.IsNil() {
+		return nil, ErrNilPointer
 	}
 
 	return &Dot{
-		Object:       innerObj.Elem(),
-		Placeholders: make(map[string]any),
+		Object:        innerObj.Elem(),
+		Placeholders:  make(map[string]any),
+		Parsers:       defaultParsers(),
+		ChannelBuffer: 1,
+		GrowLimit:     defaultGrowLimit,
 	}, nil
 }
 
+// NewSynchronized is like New, but the returned Dot guards the object with a read-write mutex,
+// so that it can be read and updated from many goroutines at once.
+// Only the access through the methods of the Dot is guarded. Values are sent into
+// and received from channels after the object is unlocked, channels being safe for concurrent use
+func NewSynchronized(obj any) (*Dot, error) {
+	d, err := New(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu = &sync.RWMutex{}
+
+	return d, nil
+}
+
 // Replace creates a new placeholder by replacing the map key with a specific value
 //
 // e.g. map path: Insert("Data.mapKey", 1), Replace("mapKey", SpecificConstant)
 // it is equivalent to: Data[SpecificConstant] = 1
 func (d *Dot) Replace(key string, value any) {
+	d.lock()
+	defer d.unlock()
+
 	d.Placeholders[key] = value
 }
 
 // Insert receives a specific path separated by dots and the value to be inserted into that path
 func (d *Dot) Insert(path string, content any) error {
-	// Save the content in the structure
-	d.Content = content
+	return d.InsertContext(context.Background(), path, content)
+}
+
+// InsertContext is like Insert, but sending the value into a channel
+// stops waiting when the context is cancelled or its deadline expires
+func (d *Dot) InsertContext(ctx context.Context, path string, content any) error {
+	compiled, err := compile(path)
+	if err != nil {
+		return err
+	}
+
+	return d.InsertPathContext(ctx, compiled, content)
+}
 
-	// Separate the received path by a point
-	parts := strings.Split(path, ".")
-	if path == "" {
-		parts = []string{}
+// InsertPath inserts the value into the precompiled path
+func (d *Dot) InsertPath(path *Path, content any) error {
+	return d.InsertPathContext(context.Background(), path, content)
+}
+
+// InsertPathContext inserts the value into the precompiled path, sending the value
+// into a channel stops waiting when the context is cancelled or its deadline expires
+func (d *Dot) InsertPathContext(ctx context.Context, path *Path, content any) error {
+	return d.apply(&insertion{ctx: ctx}, path.segments, content)
+}
+
+// apply inserts the value while the object is locked and then sends the values prepared for channels
+func (d *Dot) apply(op *insertion, segments []segment, content any) error {
+	if err := d.insertLocked(op, segments, content); err != nil {
+		return err
 	}
 
-	return d.insert(d.Object, "", parts, Var)
+	return d.deliver(op)
+}
+
+// insertLocked inserts the value while the object is locked
+func (d *Dot) insertLocked(op *insertion, segments []segment, content any) error {
+	d.lock()
+	defer d.unlock()
+
+	return d.insert(op, d.Object, trail{segments: segments}, segments, content, Var)
+}
+
+// insertion is the state of a single insertion shared by all the steps of the walk
+type insertion struct {
+	ctx   context.Context // ctx limits the time spent waiting on channels
+	sends []delivery      // sends are the values to be sent into channels once the object is unlocked
+	shift bool            // shift inserts the value at the slice index instead of replacing the element
 }
 
 // insert is called recursively to insert a value into the specified path
-func (d *Dot) insert(innerObj reflect.Value, previousPath string, parts []string, source Scenario) error {
+func (d *Dot) insert(
+	op *insertion, innerObj reflect.Value, previousPath trail, parts []segment, content any, source Scenario,
+) error {
 	// Preparing the current path on the current segment
-	currentPath := preparePath(previousPath, parts)
-	for index, fieldName := range parts {
+	currentPath := previousPath.next(len(parts))
+	for index, part := range parts {
 		// Preparing a value insertion path
-		currentPath = preparePath(previousPath, parts[:index+1])
+		currentPath = previousPath.next(index + 1)
 
 		// Removing segments already traversed from the path
 		remainingParts := parts[index:]
 
+		// The recursive descent searches the whole tree for the values where the rest of the path begins
+		if part.descent() {
+			return d.insertDescent(op, innerObj, previousPath.next(index), remainingParts, content, source)
+		}
+
+		// The path continues through the value the pointer refers to
+		innerObj = indirect(innerObj)
+
+		// The elements can be shifted only in a slice, so the last segment must be its index
+		shifted := op.shift && len(remainingParts) == 1
+		selects := part.wildcard() || part.filter != nil
+		if shifted && (selects || innerObj.Kind() != reflect.Slice && innerObj.Kind() != reflect.Interface) {
+			return &PathError{Path: currentPath.String(), Segment: part.name, Index: index, Err: ErrNotSlice}
+		}
+
+		// The wildcard applies the rest of the path to every element of the collection,
+		// the filter to the elements satisfying it
+		if selects && collection(innerObj.Kind()) {
+			return d.insertEach(op, innerObj, previousPath.next(index), remainingParts, content, source)
+		}
+
+		// The filter is never taken as a field name or a map key
+		if part.filter != nil && innerObj.Kind() != reflect.Interface {
+			return unknownPath(currentPath)
+		}
+
 		// Determine the type of current path segment
 		switch innerObj.Kind() {
 		case reflect.Map:
-			err := d.inMap(innerObj, currentPath, remainingParts)
+			err := d.inMap(op, innerObj, currentPath, remainingParts, content)
 
 			if err != nil {
 				return err
@@ -97,7 +233,7 @@ func (d *Dot) insert(innerObj reflect.Value, previousPath string, parts []string
 
 			return nil
 		case reflect.Slice:
-			err := d.inSlice(innerObj, currentPath, remainingParts)
+			err := d.inSlice(op, innerObj, currentPath, remainingParts, content)
 
 			if err != nil {
 				return err
@@ -105,51 +241,99 @@ func (d *Dot) insert(innerObj reflect.Value, previousPath string, parts []string
 
 			return nil
 		case reflect.Array:
-			err := d.inArray(innerObj, currentPath, remainingParts)
+			err := d.inArray(op, innerObj, currentPath, remainingParts, content)
 			if err != nil {
 				return err
 			}
 
 			return nil
 		case reflect.Struct:
-			innerObj = innerObj.FieldByName(fieldName)
-
-			// The value is inserted into the channel immediately,
-			// so we call the method to insert the value into the channel
-			if innerObj.Kind() == reflect.Chan {
-				return d.inChannel(innerObj, currentPath, remainingParts)
+			field, err := d.field(innerObj, part, currentPath, true)
+			if err != nil {
+				return err
 			}
+
+			innerObj = field
+		case reflect.Chan:
+			// The value is sent into the channel, the rest of the path leads inside the value
+			return d.inChannel(op, innerObj, previousPath.next(index), remainingParts, content)
 		case reflect.Interface:
-			return fmt.Errorf(
-				"the type in %s is interface{} and it is impossible to further predict the path",
-				previousPath,
-			)
+			return d.inInterface(op, innerObj, previousPath.next(index), remainingParts, content, source)
 
 		default:
 			// If it is logical to already insert a value in the specified path,
 			// but the path has not yet ended, it means that the path is specified incorrectly
 			if len(remainingParts) != 0 {
-				return fmt.Errorf(errUnknownPath, currentPath)
+				return unknownPath(currentPath)
 			}
 		}
 
 		if innerObj.Kind() == reflect.Invalid {
-			return fmt.Errorf(errUnknownPath, currentPath)
+			return unknownPath(currentPath)
 		}
 	}
 
-	return set(innerObj, currentPath, d.Content, source)
+	// The channel at the end of the path receives the value instead of being replaced with it,
+	// unless the value is a channel of the same type
+	if baseKind(innerObj.Type()) == reflect.Chan && !replacesChannel(innerObj.Type(), content) {
+		return d.inChannel(op, indirect(innerObj), currentPath, nil, content)
+	}
+
+	return d.set(innerObj, currentPath, content, source)
 }
 
 // set is the final step for inserting a value on the specified path
-func set(innerObj reflect.Value, currentPath string, content any, source Scenario) error {
+func (d *Dot) set(innerObj reflect.Value, currentPath trail, content any, source Scenario) error {
 	value := reflect.ValueOf(content)
 
-	// Checking for type matching
-	if innerObj.Type() != value.Type() && innerObj.Kind() != reflect.Interface {
-		return fmt.Errorf(
-			errMsg[source], innerObj.Type(), value.Type(), currentPath,
-		)
+	// The string inserted with InsertString is parsed into the type in the path
+	if raw, ok := content.(text); ok {
+		parsed, err := d.parseText(innerObj.Type(), string(raw), currentPath)
+		if err != nil {
+			return err
+		}
+
+		value = parsed
+	}
+
+	// An untyped nil can only be stored in types that can be nil
+	if !value.IsValid() {
+		if !nillable(innerObj.Kind()) {
+			return &TypeMismatchError{Path: currentPath.String(), Expected: innerObj.Type(), Scenario: source}
+		}
+
+		innerObj.Set(reflect.Zero(innerObj.Type()))
+		return nil
+	}
+
+	// In the conversion mode the value is converted to the type in the path if possible
+	if d.Convert {
+		value = convertValue(value, innerObj.Type())
+	}
+
+	// A value is stored in the pointer slot as a pointer to its copy
+	if innerObj.Kind() == reflect.Pointer && innerObj.Type().Elem() == value.Type() {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		innerObj.Set(pointer)
+
+		return nil
+	}
+
+	// A non-nil pointer is stored in the value slot as the value it refers to
+	if value.Kind() == reflect.Pointer && value.Type().Elem() == innerObj.Type() && !value.IsNil() {
+		innerObj.Set(value.Elem())
+		return nil
+	}
+
+	// Checking for type matching, an interface accepts any value implementing it
+	if innerObj.Type() != value.Type() && !(innerObj.Kind() == reflect.Interface && value.Type().AssignableTo(innerObj.Type())) {
+		return &TypeMismatchError{
+			Path:     currentPath.String(),
+			Expected: innerObj.Type(),
+			Got:      value.Type(),
+			Scenario: source,
+		}
 	}
 
 	innerObj.Set(value)
@@ -1,9 +1,22 @@
 package dot_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/mowshon/dot"
 	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type Part struct {
@@ -87,14 +100,19 @@ func TestSetInterface(t *testing.T) {
 	obj, err := dot.New(&data)
 	assert.Nil(t, err)
 
-	// On the path of data insertion the type interface{} is found,
+	// On the path of data insertion the empty interface{} is found,
 	// further it is impossible to define the path
-	if err := obj.Insert("N.First.Title", "New Title"); assert.Error(t, err) {
+	if err := obj.Insert("N.Second.Title", "New Title"); assert.Error(t, err) {
 		assert.ErrorContains(
-			t, err, "the type in N.First is interface{} and it is impossible to further predict the path",
+			t, err, "the type in N.Second is interface{} and it is impossible to further predict the path",
 		)
 	}
 
+	// The path continues inside the value stored in the interface{}
+	if err := obj.Insert("N.First.Title", "New Title"); assert.NoError(t, err) {
+		assert.Exactly(t, Info{Title: "New Title"}, data.N["First"])
+	}
+
 	// If the final destination in the path is interface{},
 	// then we insert the provided value into it
 	if err := obj.Insert("N.First", Info{Title: "Any Title"}); assert.NoError(t, err) {
@@ -402,3 +420,2226 @@ func TestSlice(t *testing.T) {
 		assert.ErrorContains(t, err, `invalid value "a" as a slice index`)
 	}
 }
+
+func TestGet(t *testing.T) {
+	data := Data{
+		More: Info{Title: "More Title", Pages: map[string]float64{"total": 10}},
+		C:    map[string]map[string]int{"first": {"second": 2}},
+		E:    []int{1, 2, 3},
+		G:    [3]int{4, 5, 6},
+		J:    [3]Info{{}, {Title: "J Title"}, {}},
+		K:    map[uint64]string{7: "seven"},
+		N:    map[string]any{"First": Info{Title: "Any Title"}},
+	}
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if value, err := obj.Get("More.Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "More Title", value)
+	}
+
+	if value, err := obj.Get("More.Pages.total"); assert.Nil(t, err) {
+		assert.Exactly(t, float64(10), value)
+	}
+
+	if value, err := obj.Get("C.first.second"); assert.Nil(t, err) {
+		assert.Exactly(t, 2, value)
+	}
+
+	if value, err := obj.Get("E.2"); assert.Nil(t, err) {
+		assert.Exactly(t, 3, value)
+	}
+
+	if value, err := obj.Get("J.1.Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "J Title", value)
+	}
+
+	if value, err := obj.Get("K.7"); assert.Nil(t, err) {
+		assert.Exactly(t, "seven", value)
+	}
+
+	if value, err := obj.Get("N.First.Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "Any Title", value)
+	}
+
+	if value, err := obj.Get("G"); assert.Nil(t, err) {
+		assert.Exactly(t, [3]int{4, 5, 6}, value)
+	}
+
+	if _, err := obj.Get("More.Unknown"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: More.Unknown")
+	}
+
+	if _, err := obj.Get("C.first.third"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: C.first.third")
+	}
+
+	if _, err := obj.Get("E.3"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "index 3 out of range in path E.3")
+	}
+
+	if _, err := obj.Get("G.-1"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "index -1 out of range in path G.-1 of type [3]int")
+	}
+
+	if _, err := obj.Get("E.a"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid value "a" as a slice index`)
+	}
+
+	if _, err := obj.Get("More.Title.Field"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: More.Title.Field")
+	}
+
+	if _, err := obj.Get("K.-7"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `the map key has an invalid key-value "-7" in path "K.-7" of type uint64`)
+	}
+
+	// Reading missing values must not create anything along the way
+	if _, err := obj.Get("A.first.Title"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: A.first")
+		assert.Nil(t, data.A)
+	}
+}
+
+func TestTypedAccessors(t *testing.T) {
+	data := Data{N: map[string]any{"First": nil}}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := dot.InsertAs(obj, "More.Title", "Typed Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "Typed Title", data.More.Title)
+	}
+
+	if value, err := dot.GetAs[string](obj, "More.Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "Typed Title", value)
+	}
+
+	if err := dot.InsertAs(obj, "G.2", 3); assert.Nil(t, err) {
+		assert.Exactly(t, [3]int{0, 0, 3}, data.G)
+	}
+
+	if value, err := dot.GetAs[[3]int](obj, "G"); assert.Nil(t, err) {
+		assert.Exactly(t, [3]int{0, 0, 3}, value)
+	}
+
+	if value, err := dot.GetAs[int](obj, "More.Title"); assert.Error(t, err) {
+		var mismatch *dot.TypeMismatchError
+		if assert.ErrorAs(t, err, &mismatch) {
+			assert.Exactly(t, "More.Title", mismatch.Path)
+			assert.Exactly(t, dot.Var, mismatch.Scenario)
+		}
+
+		assert.ErrorContains(t, err, "type int cannot contain a value of type string in path More.Title")
+		assert.Exactly(t, 0, value)
+	}
+
+	if err := dot.InsertAs(obj, "E.-1", "abc"); assert.Error(t, err) {
+		var mismatch *dot.TypeMismatchError
+		if assert.ErrorAs(t, err, &mismatch) {
+			assert.Exactly(t, dot.Slice, mismatch.Scenario)
+		}
+
+		assert.ErrorContains(t, err, "a slice of type int cannot contain a value of type string in path E.-1")
+	}
+
+	// A nil interface value can be read as any interface type
+	if value, err := dot.GetAs[error](obj, "N.First"); assert.Nil(t, err) {
+		assert.Nil(t, value)
+	}
+
+	if err := dot.InsertAs[error](obj, "N.First", nil); assert.Nil(t, err) {
+		assert.Nil(t, data.N["First"])
+	}
+
+	if err := dot.InsertAs[any](obj, "More.Title", nil); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "type string cannot contain a value of type nil in path More.Title")
+	}
+
+	if _, err := dot.GetAs[string](obj, "More.Unknown"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: More.Unknown")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	data := Data{
+		More: Info{Title: "More Title", Pages: map[string]float64{"total": 10, "free": 2}},
+		A:    map[string]Info{"first": {Title: "First", Pages: map[string]float64{"total": 1}}},
+		C:    map[string]map[string]int{"first": {"second": 2, "third": 3}},
+		E:    []int{1, 2, 3, 4},
+		F:    []Info{{Title: "F-First"}, {Title: "F-Second", Pages: map[string]float64{"total": 5}}},
+		G:    [3]int{4, 5, 6},
+		N:    map[string]any{"First": Info{}},
+	}
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := obj.Delete("More.Pages.free"); assert.Nil(t, err) {
+		assert.Exactly(t, map[string]float64{"total": 10}, data.More.Pages)
+	}
+
+	if err := obj.Delete("C.first.second"); assert.Nil(t, err) {
+		assert.Exactly(t, map[string]int{"third": 3}, data.C["first"])
+	}
+
+	if err := obj.Delete("A.first.Pages.total"); assert.Nil(t, err) {
+		assert.Exactly(t, "First", data.A["first"].Title)
+		assert.Empty(t, data.A["first"].Pages)
+	}
+
+	if err := obj.Delete("A.first"); assert.Nil(t, err) {
+		assert.NotContains(t, data.A, "first")
+	}
+
+	if err := obj.Delete("E.1"); assert.Nil(t, err) {
+		assert.Exactly(t, []int{1, 3, 4}, data.E)
+	}
+
+	if err := obj.Delete("E.2"); assert.Nil(t, err) {
+		assert.Exactly(t, []int{1, 3}, data.E)
+	}
+
+	if err := obj.Delete("F.1.Pages.total"); assert.Nil(t, err) {
+		assert.Empty(t, data.F[1].Pages)
+	}
+
+	if err := obj.Delete("F.0"); assert.Nil(t, err) {
+		assert.Len(t, data.F, 1)
+		assert.Exactly(t, "F-Second", data.F[0].Title)
+	}
+
+	if err := obj.Delete("G.1"); assert.Nil(t, err) {
+		assert.Exactly(t, [3]int{4, 0, 6}, data.G)
+	}
+
+	if err := obj.Delete("More.Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "", data.More.Title)
+	}
+
+	if err := obj.Delete("C.first.unknown"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: C.first.unknown")
+	}
+
+	if err := obj.Delete("More.Unknown"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: More.Unknown")
+	}
+
+	if err := obj.Delete("More.Title.Field"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: More.Title.Field")
+	}
+
+	if err := obj.Delete("E.5"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "index 5 out of range in path E.5")
+	}
+
+	if err := obj.Delete("G.3"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "index 3 out of range in path G.3 of type [3]int")
+	}
+
+	data.N["First"] = Info{Title: "N Title"}
+	if err := obj.Delete("N.First.Title"); assert.Nil(t, err) {
+		assert.Exactly(t, Info{}, data.N["First"])
+	}
+
+	if err := obj.Delete("N.First.Title.Field"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: N.First.Title.Field")
+	}
+
+	if err := obj.Delete(""); assert.Nil(t, err) {
+		assert.Exactly(t, Data{}, data)
+	}
+}
+
+func TestNestedInsertKeepsData(t *testing.T) {
+	data := Data{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	assert.Nil(t, obj.Insert("D.x.y.Title", "a"))
+	if err := obj.Insert("D.x.y.Pages.total", float64(5)); assert.Nil(t, err) {
+		assert.Exactly(t, "a", data.D["x"]["y"].Title)
+		assert.Exactly(t, float64(5), data.D["x"]["y"].Pages["total"])
+	}
+
+	assert.Nil(t, obj.Insert("A.first.Title", "First"))
+	if err := obj.Insert("A.first.Pages.total", float64(3)); assert.Nil(t, err) {
+		assert.Exactly(t, "First", data.A["first"].Title)
+		assert.Exactly(t, float64(3), data.A["first"].Pages["total"])
+	}
+
+	data.J[0] = Info{Title: "J Title", Pages: map[string]float64{"total": 1}}
+	if err := obj.Insert("J.0.Pages.free", float64(2)); assert.Nil(t, err) {
+		assert.Exactly(t, "J Title", data.J[0].Title)
+		assert.Exactly(t, map[string]float64{"total": 1, "free": 2}, data.J[0].Pages)
+	}
+
+	// A failed nested insert leaves the existing value untouched
+	if err := obj.Insert("A.first.Title.Field", "value"); assert.Error(t, err) {
+		assert.Exactly(t, "First", data.A["first"].Title)
+	}
+}
+
+type Node struct {
+	Owner *Part
+	Users map[string]*Part
+	Items []*Part
+	Next  *Node
+}
+
+func TestPointers(t *testing.T) {
+	var root *Node
+	obj, err := dot.New(&root)
+	assert.Nil(t, err)
+
+	if err := obj.Insert("Owner.Slug", "owner"); assert.Nil(t, err) {
+		assert.Exactly(t, "owner", root.Owner.Slug)
+	}
+
+	if err := obj.Insert("Next.Next.Owner.Count", 2); assert.Nil(t, err) {
+		assert.Exactly(t, 2, root.Next.Next.Owner.Count)
+	}
+
+	if err := obj.Insert("Users.alice.Slug", "alice"); assert.Nil(t, err) {
+		assert.Exactly(t, "alice", root.Users["alice"].Slug)
+	}
+
+	if err := obj.Insert("Users.alice.Count", 1); assert.Nil(t, err) {
+		assert.Exactly(t, "alice", root.Users["alice"].Slug)
+		assert.Exactly(t, 1, root.Users["alice"].Count)
+	}
+
+	if err := obj.Insert("Items.-1.User.role", "admin"); assert.Nil(t, err) {
+		assert.Exactly(t, "admin", root.Items[0].User["role"])
+	}
+
+	if err := obj.Insert("Items.0.Slug", "item"); assert.Nil(t, err) {
+		assert.Exactly(t, "item", root.Items[0].Slug)
+		assert.Exactly(t, "admin", root.Items[0].User["role"])
+	}
+
+	// A value can be inserted into a pointer slot and vice versa
+	if err := obj.Insert("Items.-1", Part{Slug: "by value"}); assert.Nil(t, err) {
+		assert.Exactly(t, "by value", root.Items[1].Slug)
+	}
+
+	if err := obj.Insert("Next.Owner", Part{Slug: "next"}); assert.Nil(t, err) {
+		assert.Exactly(t, "next", root.Next.Owner.Slug)
+	}
+
+	info := Info{}
+	if infoObj, err := dot.New(&info); assert.Nil(t, err) {
+		if err := infoObj.Insert("Pipe", &Part{Slug: "by pointer"}); assert.Nil(t, err) {
+			assert.Exactly(t, "by pointer", (<-info.Pipe).Slug)
+		}
+	}
+
+	if err := obj.Insert("Owner", (*Info)(nil)); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "type *dot_test.Part cannot contain a value of type *dot_test.Info in path Owner")
+	}
+
+	if value, err := obj.Get("Next.Next.Owner.Count"); assert.Nil(t, err) {
+		assert.Exactly(t, 2, value)
+	}
+
+	if value, err := obj.Get("Users.alice.Slug"); assert.Nil(t, err) {
+		assert.Exactly(t, "alice", value)
+	}
+
+	// Reading and deleting never allocate nil pointers
+	if _, err := obj.Get("Next.Next.Next.Owner"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: Next.Next.Next.Owner")
+		assert.Nil(t, root.Next.Next.Next)
+	}
+
+	if err := obj.Delete("Next.Next.Next.Owner"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: Next.Next.Next.Owner")
+		assert.Nil(t, root.Next.Next.Next)
+	}
+
+	if err := obj.Delete("Users.alice.Count"); assert.Nil(t, err) {
+		assert.Exactly(t, 0, root.Users["alice"].Count)
+	}
+
+	if err := obj.Delete("Next.Next"); assert.Nil(t, err) {
+		assert.Nil(t, root.Next.Next)
+	}
+}
+
+func TestInterfaceTraversal(t *testing.T) {
+	data := Data{
+		N: map[string]any{
+			"config": map[string]any{"debug": false},
+			"list":   []any{"a"},
+			"part":   &Part{Slug: "part"},
+		},
+	}
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := obj.Insert("N.config.debug", true); assert.Nil(t, err) {
+		assert.Exactly(t, map[string]any{"debug": true}, data.N["config"])
+	}
+
+	if err := obj.Insert("N.list.-1", "b"); assert.Nil(t, err) {
+		assert.Exactly(t, []any{"a", "b"}, data.N["list"])
+	}
+
+	if err := obj.Insert("N.list.0", 1); assert.Nil(t, err) {
+		assert.Exactly(t, []any{1, "b"}, data.N["list"])
+	}
+
+	if err := obj.Insert("N.part.Count", 3); assert.Nil(t, err) {
+		assert.Exactly(t, &Part{Slug: "part", Count: 3}, data.N["part"])
+	}
+
+	if err := obj.Insert("N.part.Unknown", 3); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: N.part.Unknown")
+	}
+
+	if err := obj.Insert("N.tree.users.-1", "alice"); assert.Error(t, err) {
+		assert.ErrorContains(
+			t, err, "the type in N.tree is interface{} and it is impossible to further predict the path",
+		)
+	}
+
+	// Containers are created in nil interfaces the way encoding/json would represent them
+	obj.AutoCreate = true
+
+	if err := obj.Insert("N.tree.users.-1", "alice"); assert.Nil(t, err) {
+		assert.Exactly(t, map[string]any{"users": []any{"alice"}}, data.N["tree"])
+	}
+
+	if err := obj.Insert("N.tree.users.-1.name", "bob"); assert.Nil(t, err) {
+		assert.Exactly(t, []any{"alice", map[string]any{"name": "bob"}}, data.N["tree"].(map[string]any)["users"])
+	}
+
+	if value, err := obj.Get("N.tree.users.1.name"); assert.Nil(t, err) {
+		assert.Exactly(t, "bob", value)
+	}
+
+	if err := obj.Delete("N.tree.users.0"); assert.Nil(t, err) {
+		assert.Exactly(t, []any{map[string]any{"name": "bob"}}, data.N["tree"].(map[string]any)["users"])
+	}
+}
+
+type Account struct {
+	UserName string            `json:"user_name"`
+	Password string            `json:"-"`
+	Email    string            `json:"email_address" dot:"email"`
+	Internal string            `dot:"-"`
+	Options  map[string]string `json:"options,omitempty"`
+	Profile  Part              `json:",omitempty"`
+}
+
+func TestStructTags(t *testing.T) {
+	data := Account{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Without TagKey only the "dot" tag and the names of the fields are used
+	if err := obj.Insert("email", "first@example.com"); assert.Nil(t, err) {
+		assert.Exactly(t, "first@example.com", data.Email)
+	}
+
+	if err := obj.Insert("UserName", "first"); assert.Nil(t, err) {
+		assert.Exactly(t, "first", data.UserName)
+	}
+
+	if err := obj.Insert("user_name", "first"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: user_name")
+	}
+
+	if err := obj.Insert("Internal", "value"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: Internal")
+	}
+
+	obj.TagKey = "json"
+
+	if err := obj.Insert("user_name", "second"); assert.Nil(t, err) {
+		assert.Exactly(t, "second", data.UserName)
+	}
+
+	if err := obj.Insert("options.theme", "dark"); assert.Nil(t, err) {
+		assert.Exactly(t, "dark", data.Options["theme"])
+	}
+
+	// A tag without a name keeps the name of the field
+	if err := obj.Insert("Profile.Slug", "profile"); assert.Nil(t, err) {
+		assert.Exactly(t, "profile", data.Profile.Slug)
+	}
+
+	// The "dot" tag takes precedence over the tag from TagKey
+	if err := obj.Insert("email", "second@example.com"); assert.Nil(t, err) {
+		assert.Exactly(t, "second@example.com", data.Email)
+	}
+
+	if err := obj.Insert("email_address", "third@example.com"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: email_address")
+	}
+
+	if err := obj.Insert("Password", "secret"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: Password")
+		assert.Exactly(t, "", data.Password)
+	}
+
+	if value, err := obj.Get("user_name"); assert.Nil(t, err) {
+		assert.Exactly(t, "second", value)
+	}
+
+	if _, err := obj.Get("Password"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: Password")
+	}
+
+	if err := obj.Delete("options.theme"); assert.Nil(t, err) {
+		assert.Empty(t, data.Options)
+	}
+}
+
+func TestPathSyntax(t *testing.T) {
+	data := Data{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := obj.Insert(`B["example.com"]`, "double quotes"); assert.Nil(t, err) {
+		assert.Exactly(t, "double quotes", data.B["example.com"])
+	}
+
+	if err := obj.Insert(`B['v1.2']`, "single quotes"); assert.Nil(t, err) {
+		assert.Exactly(t, "single quotes", data.B["v1.2"])
+	}
+
+	if err := obj.Insert(`B.v2\.0`, "escaped dot"); assert.Nil(t, err) {
+		assert.Exactly(t, "escaped dot", data.B["v2.0"])
+	}
+
+	if err := obj.Insert(`B["say \"hi\""]`, "escaped quote"); assert.Nil(t, err) {
+		assert.Exactly(t, "escaped quote", data.B[`say "hi"`])
+	}
+
+	if err := obj.Insert(`D["a.b"]["c.d"].Title`, "nested"); assert.Nil(t, err) {
+		assert.Exactly(t, "nested", data.D["a.b"]["c.d"].Title)
+	}
+
+	if err := obj.Insert("F[-1].Title", "appended"); assert.Nil(t, err) {
+		assert.Exactly(t, "appended", data.F[0].Title)
+	}
+
+	if err := obj.Insert("F[0].Pages.total", float64(1)); assert.Nil(t, err) {
+		assert.Exactly(t, float64(1), data.F[0].Pages["total"])
+	}
+
+	if err := obj.Insert("J.[1].Title", "dot before bracket"); assert.Nil(t, err) {
+		assert.Exactly(t, "dot before bracket", data.J[1].Title)
+	}
+
+	if value, err := obj.Get(`D['a.b']["c.d"].Title`); assert.Nil(t, err) {
+		assert.Exactly(t, "nested", value)
+	}
+
+	if err := obj.Delete(`B["example.com"]`); assert.Nil(t, err) {
+		assert.NotContains(t, data.B, "example.com")
+	}
+
+	floats := make(map[float64]string)
+	if floatObj, err := dot.New(&floats); assert.Nil(t, err) {
+		if err := floatObj.Insert(`["5.2"]`, "float key"); assert.Nil(t, err) {
+			assert.Exactly(t, "float key", floats[5.2])
+		}
+	}
+
+	// Segments containing special characters are written in brackets in error messages
+	if err := obj.Insert(`D["a.b"]["c.d"].Unknown`, "value"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `unknown path: D["a.b"]["c.d"].Unknown`)
+	}
+
+	if err := obj.Insert(`B["unterminated`, "value"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid path "B["unterminated": unterminated quoted key at position 1`)
+	}
+
+	if err := obj.Insert("E[0", 1); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid path "E[0": missing closing bracket at position 1`)
+	}
+
+	if err := obj.Insert(`B["key"x]`, "value"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid path "B["key"x]": missing closing bracket at position 7`)
+	}
+
+	if _, err := obj.Get("E[0]x"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid path "E[0]x": unexpected character 'x' at position 4`)
+	}
+
+	if err := obj.Delete(`B.key\`); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid path "B.key\": nothing to escape at position 5`)
+	}
+}
+
+func TestCompiledPath(t *testing.T) {
+	data := Data{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	title := dot.MustCompile(`A["first.key"].Title`)
+	assert.Exactly(t, `A["first.key"].Title`, title.String())
+
+	if err := obj.InsertPath(title, "Compiled Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "Compiled Title", data.A["first.key"].Title)
+	}
+
+	// The same path can be used any number of times
+	if err := obj.InsertPath(title, "New Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "New Title", data.A["first.key"].Title)
+	}
+
+	if value, err := obj.GetPath(title); assert.Nil(t, err) {
+		assert.Exactly(t, "New Title", value)
+	}
+
+	if err := obj.DeletePath(dot.MustCompile(`A["first.key"]`)); assert.Nil(t, err) {
+		assert.Empty(t, data.A)
+	}
+
+	if _, err := obj.GetPath(title); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `unknown path: A["first.key"]`)
+	}
+
+	if path, err := dot.Compile("A[first"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `invalid path "A[first": missing closing bracket at position 1`)
+		assert.Nil(t, path)
+	}
+
+	assert.Panics(t, func() {
+		dot.MustCompile("A[first")
+	})
+}
+
+func TestPathReuse(t *testing.T) {
+	path := dot.MustCompile("Title")
+
+	// The same path is resolved for each type it is used with
+	data, info := Data{}, Info{}
+	dataObj, _ := dot.New(&data)
+	infoObj, _ := dot.New(&info)
+
+	if err := infoObj.InsertPath(path, "Info Title"); assert.Nil(t, err) {
+		assert.Exactly(t, "Info Title", info.Title)
+	}
+
+	if err := dataObj.InsertPath(path, "Data Title"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: Title")
+	}
+
+	if value, err := infoObj.GetPath(path); assert.Nil(t, err) {
+		assert.Exactly(t, "Info Title", value)
+	}
+
+	// Placeholders replaced between the calls are always taken into account
+	key := dot.MustCompile("L.Current")
+	dataObj.Replace("Current", FirstKey)
+	assert.Nil(t, dataObj.InsertPath(key, "first"))
+
+	dataObj.Replace("Current", SecondKey)
+	if err := dataObj.InsertPath(key, "second"); assert.Nil(t, err) {
+		assert.Exactly(t, map[Key]string{FirstKey: "first", SecondKey: "second"}, data.L)
+	}
+
+	// The change of TagKey is taken into account too
+	account := Account{}
+	accountObj, _ := dot.New(&account)
+	name := dot.MustCompile("user_name")
+
+	if err := accountObj.InsertPath(name, "first"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "unknown path: user_name")
+	}
+
+	accountObj.TagKey = "json"
+	if err := accountObj.InsertPath(name, "second"); assert.Nil(t, err) {
+		assert.Exactly(t, "second", account.UserName)
+	}
+}
+
+func TestSynchronized(t *testing.T) {
+	data := Data{}
+	obj, err := dot.NewSynchronized(&data)
+	assert.Nil(t, err)
+
+	if _, err := dot.NewSynchronized(data); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "expected a pointer")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			key := fmt.Sprintf("key%d", i)
+			obj.Replace(key, key)
+
+			for j := 0; j < 100; j++ {
+				assert.Nil(t, obj.Insert("B."+key, key))
+				assert.Nil(t, obj.Insert("More.Pages."+key, float64(j)))
+				assert.Nil(t, obj.Insert("E.-1", j))
+
+				if value, err := obj.Get("B." + key); assert.Nil(t, err) {
+					assert.Exactly(t, key, value)
+				}
+
+				if _, err := dot.GetAs[float64](obj, "More.Pages."+key); assert.Nil(t, err) {
+					assert.Nil(t, obj.Delete("More.Pages."+key))
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, data.B, 10)
+	assert.Len(t, data.E, 1000)
+	assert.Empty(t, data.More.Pages)
+}
+
+func TestTypedErrors(t *testing.T) {
+	data := Data{N: map[string]any{}}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if _, err := dot.New(data); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNotPointer)
+	}
+
+	if err := obj.Insert("E[0", 1); assert.Error(t, err) {
+		var syntax *dot.SyntaxError
+		if assert.ErrorAs(t, err, &syntax) {
+			assert.Exactly(t, "E[0", syntax.Path)
+			assert.Exactly(t, 1, syntax.Position)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrSyntax)
+	}
+
+	if err := obj.Insert("More.Title.Unknown", "value"); assert.Error(t, err) {
+		var pathErr *dot.PathError
+		if assert.ErrorAs(t, err, &pathErr) {
+			assert.Exactly(t, "More.Title.Unknown", pathErr.Path)
+			assert.Exactly(t, "Unknown", pathErr.Segment)
+			assert.Exactly(t, 2, pathErr.Index)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+	}
+
+	if _, err := obj.Get("A.missing.Title"); assert.Error(t, err) {
+		var pathErr *dot.PathError
+		if assert.ErrorAs(t, err, &pathErr) {
+			assert.Exactly(t, "missing", pathErr.Segment)
+			assert.Exactly(t, 1, pathErr.Index)
+		}
+	}
+
+	if err := obj.Insert("N.empty.Title", "value"); assert.Error(t, err) {
+		var pathErr *dot.PathError
+		if assert.ErrorAs(t, err, &pathErr) {
+			assert.Exactly(t, "N.empty", pathErr.Path)
+			assert.Exactly(t, "Title", pathErr.Segment)
+			assert.Exactly(t, 2, pathErr.Index)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrNilInterface)
+		assert.False(t, errors.Is(err, dot.ErrUnknownPath))
+	}
+
+	if err := obj.Insert("More.Title", 1); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	if err := obj.Insert("E.x", 1); assert.Error(t, err) {
+		var invalid *dot.InvalidIndexError
+		if assert.ErrorAs(t, err, &invalid) {
+			assert.Exactly(t, "x", invalid.Value)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrInvalidIndex)
+	}
+
+	if err := obj.Insert("G.5", 1); assert.Error(t, err) {
+		var outOfRange *dot.IndexOutOfRangeError
+		if assert.ErrorAs(t, err, &outOfRange) {
+			assert.Exactly(t, 5, outOfRange.Index)
+			assert.Exactly(t, 3, outOfRange.Length)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+	}
+
+	if _, err := obj.Get("E.0"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+	}
+
+	if err := obj.Insert("K.abc", "value"); assert.Error(t, err) {
+		var invalid *dot.InvalidKeyError
+		if assert.ErrorAs(t, err, &invalid) {
+			assert.Exactly(t, "abc", invalid.Key)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrInvalidKey)
+	}
+
+	if err := obj.Insert("M.unknown", "value"); assert.Error(t, err) {
+		var unknown *dot.UnknownPlaceholderError
+		if assert.ErrorAs(t, err, &unknown) {
+			assert.Exactly(t, "unknown", unknown.Key)
+			assert.Exactly(t, "M.unknown", unknown.Path)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrUnknownPlaceholder)
+	}
+
+	obj.Replace("wrong", "key")
+	if err := obj.Insert("L.wrong", "value"); assert.Error(t, err) {
+		var mismatch *dot.TypeMismatchError
+		if assert.ErrorAs(t, err, &mismatch) {
+			assert.Exactly(t, dot.Key, mismatch.Scenario)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+}
+
+type Metrics struct {
+	Count   int
+	Small   int8
+	Big     int64
+	Size    uint16
+	Ratio   float32
+	Precise float64
+	Enabled bool
+	Name    Key
+	Label   string
+	Codes   map[string]Key
+	Limit   *int64
+}
+
+func TestConvert(t *testing.T) {
+	data := Metrics{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Without the conversion mode the types must match exactly
+	if err := obj.Insert("Big", 5); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	obj.Convert = true
+
+	if err := obj.Insert("Big", 5); assert.Nil(t, err) {
+		assert.Exactly(t, int64(5), data.Big)
+	}
+
+	// Numbers decoded from JSON are float64
+	if err := obj.Insert("Count", float64(42)); assert.Nil(t, err) {
+		assert.Exactly(t, 42, data.Count)
+	}
+
+	if err := obj.Insert("Size", 65535); assert.Nil(t, err) {
+		assert.Exactly(t, uint16(65535), data.Size)
+	}
+
+	if err := obj.Insert("Ratio", 0.5); assert.Nil(t, err) {
+		assert.Exactly(t, float32(0.5), data.Ratio)
+	}
+
+	if err := obj.Insert("Precise", int32(7)); assert.Nil(t, err) {
+		assert.Exactly(t, float64(7), data.Precise)
+	}
+
+	if err := obj.Insert("Name", uint(3)); assert.Nil(t, err) {
+		assert.Exactly(t, Key(3), data.Name)
+	}
+
+	if err := obj.Insert("Codes.first", 1); assert.Nil(t, err) {
+		assert.Exactly(t, SecondKey, data.Codes["first"])
+	}
+
+	if err := obj.Insert("Limit", 10); assert.Nil(t, err) {
+		assert.Exactly(t, int64(10), *data.Limit)
+	}
+
+	// Strings are parsed for primitive types
+	if err := obj.Insert("Small", "-12"); assert.Nil(t, err) {
+		assert.Exactly(t, int8(-12), data.Small)
+	}
+
+	if err := obj.Insert("Enabled", "true"); assert.Nil(t, err) {
+		assert.True(t, data.Enabled)
+	}
+
+	if err := obj.Insert("Precise", "2.5"); assert.Nil(t, err) {
+		assert.Exactly(t, 2.5, data.Precise)
+	}
+
+	// Values that do not fit are rejected
+	if err := obj.Insert("Small", 300); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "type int8 cannot contain a value of type int in path Small")
+	}
+
+	if err := obj.Insert("Size", -1); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	if err := obj.Insert("Count", 1.5); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	if err := obj.Insert("Ratio", 1e300); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	if err := obj.Insert("Precise", int64(1<<53+1)); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	if err := obj.Insert("Small", "abc"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "type int8 cannot contain a value of type string in path Small")
+	}
+
+	if err := obj.Insert("Enabled", 1); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	// Integers are not converted to strings
+	if err := obj.Insert("Label", 65); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+	}
+
+	assert.Exactly(t, int8(-12), data.Small)
+	assert.Exactly(t, 42, data.Count)
+}
+
+type Color struct {
+	R, G, B uint8
+}
+
+type Settings struct {
+	Timeout  time.Duration
+	Deadline time.Time
+	Address  net.IP
+	Total    *big.Int
+	Retries  int
+	Backoff  *time.Duration
+	Ratio    float32
+	Debug    bool
+	Name     string
+	Theme    Color
+	Extra    map[string]any
+	Delays   []time.Duration
+}
+
+func TestInsertString(t *testing.T) {
+	data := Settings{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := obj.InsertString("Timeout", "5s"); assert.Nil(t, err) {
+		assert.Exactly(t, 5*time.Second, data.Timeout)
+	}
+
+	if err := obj.InsertString("Backoff", "150ms"); assert.Nil(t, err) {
+		assert.Exactly(t, 150*time.Millisecond, *data.Backoff)
+	}
+
+	if err := obj.InsertString("Delays.-1", "1m"); assert.Nil(t, err) {
+		assert.Exactly(t, []time.Duration{time.Minute}, data.Delays)
+	}
+
+	// Types implementing encoding.TextUnmarshaler parse the string themselves
+	if err := obj.InsertString("Deadline", "2023-07-01T10:00:00Z"); assert.Nil(t, err) {
+		assert.Exactly(t, time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC), data.Deadline)
+	}
+
+	if err := obj.InsertString("Address", "192.168.0.1"); assert.Nil(t, err) {
+		assert.Exactly(t, "192.168.0.1", data.Address.String())
+	}
+
+	if err := obj.InsertString("Total", "123456789012345678901234567890"); assert.Nil(t, err) {
+		assert.Exactly(t, "123456789012345678901234567890", data.Total.String())
+	}
+
+	// Primitive types are parsed as such
+	if err := obj.InsertString("Retries", "3"); assert.Nil(t, err) {
+		assert.Exactly(t, 3, data.Retries)
+	}
+
+	if err := obj.InsertString("Ratio", "0.25"); assert.Nil(t, err) {
+		assert.Exactly(t, float32(0.25), data.Ratio)
+	}
+
+	if err := obj.InsertString("Debug", "true"); assert.Nil(t, err) {
+		assert.True(t, data.Debug)
+	}
+
+	if err := obj.InsertString("Name", "server"); assert.Nil(t, err) {
+		assert.Exactly(t, "server", data.Name)
+	}
+
+	if err := obj.InsertString("Extra.key", "value"); assert.Nil(t, err) {
+		assert.Exactly(t, "value", data.Extra["key"])
+	}
+
+	// Other types need a registered parser
+	if err := obj.InsertString("Theme", "10,20,30"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "type dot_test.Color cannot contain a value of type string in path Theme")
+	}
+
+	obj.RegisterParser(reflect.TypeOf(Color{}), func(value string) (any, error) {
+		var color Color
+		_, err := fmt.Sscanf(strings.ReplaceAll(value, ",", " "), "%d %d %d", &color.R, &color.G, &color.B)
+		return color, err
+	})
+
+	if err := obj.InsertString("Theme", "10,20,30"); assert.Nil(t, err) {
+		assert.Exactly(t, Color{10, 20, 30}, data.Theme)
+	}
+
+	if err := obj.InsertString("Theme", "red"); assert.Error(t, err) {
+		var parseErr *dot.ParseError
+		if assert.ErrorAs(t, err, &parseErr) {
+			assert.Exactly(t, "red", parseErr.Value)
+			assert.Exactly(t, "Theme", parseErr.Path)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrParse)
+	}
+
+	if err := obj.InsertString("Timeout", "soon"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, `cannot parse "soon" as time.Duration in path Timeout`)
+	}
+
+	if err := obj.InsertString("Retries", "many"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrParse)
+		assert.Exactly(t, 3, data.Retries)
+	}
+
+	if err := obj.InsertString("Address", "not an ip"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrParse)
+	}
+}
+
+type Point struct {
+	X, Y int
+}
+
+type Schedule struct {
+	Days   map[time.Weekday]string
+	Hosts  map[netip.Addr]string
+	Grid   map[[2]int]string
+	Points map[Point]string
+	Times  map[time.Time]int
+	Pairs  map[[2]time.Weekday]bool
+}
+
+func TestMapKeyParsing(t *testing.T) {
+	data := Schedule{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Keys implementing encoding.TextUnmarshaler parse the segment themselves
+	if err := obj.Insert(`Hosts["10.0.0.1"]`, "gateway"); assert.Nil(t, err) {
+		assert.Exactly(t, "gateway", data.Hosts[netip.MustParseAddr("10.0.0.1")])
+	}
+
+	if err := obj.Insert(`Times["2023-07-01T10:00:00Z"]`, 1); assert.Nil(t, err) {
+		assert.Exactly(t, 1, data.Times[time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)])
+	}
+
+	if err := obj.Insert("Hosts.unknown", "gateway"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrInvalidKey)
+	}
+
+	// Arrays and structs are written as comma-separated elements
+	if err := obj.Insert("Grid[1,2]", "cell"); assert.Nil(t, err) {
+		assert.Exactly(t, "cell", data.Grid[[2]int{1, 2}])
+	}
+
+	if err := obj.Insert("Points[3, -4]", "point"); assert.Nil(t, err) {
+		assert.Exactly(t, "point", data.Points[Point{3, -4}])
+	}
+
+	if value, err := obj.Get("Points.3,-4"); assert.Nil(t, err) {
+		assert.Exactly(t, "point", value)
+	}
+
+	if err := obj.Insert("Grid[1,x]", "cell"); assert.Error(t, err) {
+		var invalid *dot.InvalidKeyError
+		if assert.ErrorAs(t, err, &invalid) {
+			assert.Exactly(t, "1,x", invalid.Key)
+			assert.Exactly(t, "Grid.1,x", invalid.Path)
+		}
+
+		// The error of the parser is available only through Unwrap
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		assert.EqualError(t, err, `the map key has an invalid key-value "1,x" in path "Grid.1,x" of type [2]int`)
+	}
+
+	// A segment of a different shape is the name of a missing placeholder
+	if err := obj.Insert("Grid[1,2,3]", "cell"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPlaceholder)
+	}
+
+	// Primitive keys of named types are written as their underlying type
+	if err := obj.Insert("Days.1", "gym"); assert.Nil(t, err) {
+		assert.Exactly(t, "gym", data.Days[time.Monday])
+	}
+
+	// Other keys are parsed by the registered parsers
+	weekdays := map[string]time.Weekday{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		weekdays[day.String()] = day
+	}
+
+	obj.RegisterParser(reflect.TypeOf(time.Sunday), func(value string) (any, error) {
+		if day, ok := weekdays[value]; ok {
+			return day, nil
+		}
+
+		return nil, fmt.Errorf("unknown weekday %s", value)
+	})
+
+	if err := obj.Insert("Days.Friday", "party"); assert.Nil(t, err) {
+		assert.Exactly(t, "party", data.Days[time.Friday])
+	}
+
+	if err := obj.Insert("Pairs[Saturday,Sunday]", true); assert.Nil(t, err) {
+		assert.True(t, data.Pairs[[2]time.Weekday{time.Saturday, time.Sunday}])
+	}
+
+	if err := obj.Insert("Days.Someday", "never"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrInvalidKey)
+
+		var invalid *dot.InvalidKeyError
+		if assert.ErrorAs(t, err, &invalid) {
+			assert.EqualError(t, invalid.Err, "unknown weekday Someday")
+		}
+	}
+
+	// Placeholders keep the highest priority
+	obj.Replace("Friday", time.Sunday)
+	if err := obj.Insert("Days.Friday", "rest"); assert.Nil(t, err) {
+		assert.Exactly(t, "rest", data.Days[time.Sunday])
+		assert.Exactly(t, "party", data.Days[time.Friday])
+	}
+
+	obj.Replace("origin", Point{})
+	if err := obj.Insert("Points.origin", "zero"); assert.Nil(t, err) {
+		assert.Exactly(t, "zero", data.Points[Point{}])
+	}
+
+	// Keys parsed by the parsers of one Dot are not reused by another one
+	other := Schedule{}
+	otherObj, err := dot.New(&other)
+	assert.Nil(t, err)
+
+	if err := otherObj.Insert("Days.Friday", "party"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrInvalidKey)
+	}
+}
+
+func TestChannelModes(t *testing.T) {
+	data := Data{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Nil channels are created with the configured capacity
+	obj.ChannelBuffer = 3
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, obj.Insert("I", i))
+	}
+
+	assert.Exactly(t, 3, cap(data.I))
+
+	// In the non-blocking mode a full channel is reported at once
+	obj.NonBlocking = true
+	if err := obj.Insert("I", 4); assert.Error(t, err) {
+		var channelErr *dot.ChannelError
+		if assert.ErrorAs(t, err, &channelErr) {
+			assert.Exactly(t, "I", channelErr.Path)
+			assert.Exactly(t, reflect.TypeOf(data.I), channelErr.Type)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrChannelFull)
+		assert.ErrorContains(t, err, "cannot send a value into the channel of type chan int in path I: channel is full")
+	}
+
+	// A blocking send waits until the context is done
+	obj.NonBlocking = false
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := obj.InsertContext(ctx, "I", 4); assert.Error(t, err) {
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	<-data.I
+	if err := obj.InsertContext(cancelled, "I", 4); assert.Error(t, err) {
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Exactly(t, 2, len(data.I))
+	}
+
+	if err := obj.InsertContext(context.Background(), "I", 4); assert.Nil(t, err) {
+		assert.Exactly(t, 3, len(data.I))
+	}
+
+	// Sending on a closed channel does not panic
+	close(data.I)
+	if err := obj.Insert("I", 5); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrChannelClosed)
+	}
+
+	// An unbuffered channel receives the value as soon as someone is reading
+	unbuffered := Data{}
+	unbufferedObj, err := dot.New(&unbuffered)
+	assert.Nil(t, err)
+
+	unbufferedObj.ChannelBuffer = 0
+	unbufferedObj.NonBlocking = true
+	if err := unbufferedObj.Insert("H.Title", "dropped"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrChannelFull)
+		assert.Exactly(t, 0, cap(unbuffered.H))
+	}
+
+	received := make(chan Info)
+	go func() {
+		received <- <-unbuffered.H
+	}()
+
+	unbufferedObj.NonBlocking = false
+	if err := unbufferedObj.InsertContext(context.Background(), "H.Title", "delivered"); assert.Nil(t, err) {
+		assert.Exactly(t, "delivered", (<-received).Title)
+	}
+}
+
+func TestReceive(t *testing.T) {
+	data := Data{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	obj.ChannelBuffer = 3
+	for i := 1; i <= 3; i++ {
+		assert.Nil(t, obj.Insert("I", i))
+	}
+
+	if value, ok, err := obj.Receive("I"); assert.Nil(t, err) {
+		assert.True(t, ok)
+		assert.Exactly(t, 1, value)
+	}
+
+	if values, err := obj.Drain("I"); assert.Nil(t, err) {
+		assert.Exactly(t, []int{2, 3}, values)
+	}
+
+	if values, err := obj.Drain("I"); assert.Nil(t, err) {
+		assert.Exactly(t, []int{}, values)
+	}
+
+	// Channels nested anywhere in the structure are found by the path
+	assert.Nil(t, obj.Insert("A.first.Pipe.Slug", "piped"))
+	if value, ok, err := obj.Receive("A.first.Pipe"); assert.Nil(t, err) {
+		assert.True(t, ok)
+		assert.Exactly(t, "piped", value.(Part).Slug)
+	}
+
+	// In the non-blocking mode an empty channel is reported at once
+	obj.NonBlocking = true
+	if _, _, err := obj.Receive("I"); assert.Error(t, err) {
+		var channelErr *dot.ChannelError
+		if assert.ErrorAs(t, err, &channelErr) {
+			assert.Exactly(t, "I", channelErr.Path)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrChannelEmpty)
+		assert.ErrorContains(t, err, "cannot receive a value from type chan int in path I: channel is empty")
+	}
+
+	// A blocking receive waits until the context is done
+	obj.NonBlocking = false
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := obj.ReceiveContext(ctx, "I"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+
+	// The object is not locked while waiting, so the same Dot can send the value
+	synchronized := Data{I: make(chan int)}
+	syncObj, err := dot.NewSynchronized(&synchronized)
+	assert.Nil(t, err)
+
+	go func() {
+		_ = syncObj.Insert("I", 42)
+	}()
+
+	if value, ok, err := syncObj.Receive("I"); assert.Nil(t, err) {
+		assert.True(t, ok)
+		assert.Exactly(t, 42, value)
+	}
+
+	// A closed channel gives the zero value
+	close(data.I)
+	if value, ok, err := obj.Receive("I"); assert.Nil(t, err) {
+		assert.False(t, ok)
+		assert.Exactly(t, 0, value)
+	}
+
+	if _, _, err := obj.Receive("H"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNilChannel)
+	}
+
+	if _, err := obj.Drain("E"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNotChannel)
+	}
+
+	if _, _, err := obj.Receive("Unknown"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+	}
+}
+
+type Pipeline struct {
+	Queues  map[string]chan int
+	Workers []chan Part
+	Stages  [2]chan int
+	Jobs    map[string][]chan string
+	Out     chan<- int
+	In      <-chan int
+	Backlog *chan int
+}
+
+func TestChannelsAnywhere(t *testing.T) {
+	data := Pipeline{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Channels stored in maps are created and written back
+	if err := obj.Insert("Queues.fast", 1); assert.Nil(t, err) {
+		assert.Exactly(t, 1, <-data.Queues["fast"])
+	}
+
+	// Channels in slices and arrays
+	if err := obj.Insert("Workers.-1.Slug", "first"); assert.Nil(t, err) {
+		assert.Len(t, data.Workers, 1)
+		assert.Exactly(t, "first", (<-data.Workers[0]).Slug)
+	}
+
+	if err := obj.Insert("Workers.0.User.role", "admin"); assert.Nil(t, err) {
+		assert.Exactly(t, "admin", (<-data.Workers[0]).User["role"])
+	}
+
+	if err := obj.Insert("Stages.1", 2); assert.Nil(t, err) {
+		assert.Nil(t, data.Stages[0])
+		assert.Exactly(t, 2, <-data.Stages[1])
+	}
+
+	if err := obj.Insert("Jobs.build.-1", "compile"); assert.Nil(t, err) {
+		assert.Exactly(t, "compile", <-data.Jobs["build"][0])
+	}
+
+	if value, ok, err := obj.Receive("Queues.slow"); assert.Error(t, err) {
+		assert.Nil(t, value)
+		assert.False(t, ok)
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+	}
+
+	// Pointers to channels are allocated on the way
+	if err := obj.Insert("Backlog", 3); assert.Nil(t, err) {
+		assert.Exactly(t, 3, <-*data.Backlog)
+	}
+
+	// Directional channels
+	if err := obj.Insert("Out", 4); assert.Nil(t, err) {
+		assert.Exactly(t, 1, len(data.Out))
+	}
+
+	if err := obj.Insert("In", 5); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrTypeMismatch)
+		assert.ErrorContains(t, err, "channel of type <-chan int cannot contain a value of type int in path In")
+	}
+
+	if _, _, err := obj.Receive("Out"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNotChannel)
+	}
+
+	// A channel of the same type is stored in the slot instead of being sent
+	queue := make(chan int, 1)
+	if err := obj.Insert("Queues.spare", queue); assert.Nil(t, err) {
+		assert.Exactly(t, queue, data.Queues["spare"])
+		assert.Exactly(t, 0, len(queue))
+	}
+
+	worker := make(chan Part, 1)
+	if err := obj.Insert("Workers.-1", worker); assert.Nil(t, err) {
+		assert.Len(t, data.Workers, 2)
+		assert.Exactly(t, worker, data.Workers[1])
+	}
+
+	if err := obj.Insert("Backlog", &queue); assert.Nil(t, err) {
+		assert.Exactly(t, queue, *data.Backlog)
+	}
+
+	// A channel passed to New as the root object
+	root := make(chan int, 1)
+	if rootObj, err := dot.New(&root); assert.Nil(t, err) {
+		if err := rootObj.Insert("", 6); assert.Nil(t, err) {
+			assert.Exactly(t, 6, <-root)
+		}
+	}
+
+	var rootPointer *chan Info
+	if rootObj, err := dot.New(&rootPointer); assert.Nil(t, err) {
+		if err := rootObj.Insert("Title", "root"); assert.Nil(t, err) {
+			assert.Exactly(t, "root", (<-*rootPointer).Title)
+		}
+	}
+}
+
+type Base struct {
+	ID   int
+	Name string
+}
+
+type Meta struct {
+	ID      string
+	Version int
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type Entity struct {
+	Base
+	*Meta
+	*Audit
+	Title string
+}
+
+type Shadow struct {
+	Base
+	ID string
+}
+
+func TestEmbeddedFields(t *testing.T) {
+	data := Entity{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Promoted fields
+	if err := obj.Insert("Name", "entity"); assert.Nil(t, err) {
+		assert.Exactly(t, "entity", data.Base.Name)
+	}
+
+	// Reading a field promoted through a nil pointer does not allocate it
+	if _, err := obj.Get("Version"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+		assert.Nil(t, data.Meta)
+	}
+
+	// Inserting it allocates the embedded struct
+	if err := obj.Insert("Version", 2); assert.Nil(t, err) {
+		assert.Exactly(t, 2, data.Meta.Version)
+	}
+
+	obj.TagKey = "json"
+	if err := obj.Insert("created_by", "admin"); assert.Nil(t, err) {
+		assert.Exactly(t, "admin", data.Audit.CreatedBy)
+	}
+
+	// The same name declared in several embedded structs at the same depth is ambiguous
+	if err := obj.Insert("ID", 1); assert.Error(t, err) {
+		var ambiguous *dot.AmbiguousFieldError
+		if assert.ErrorAs(t, err, &ambiguous) {
+			assert.Exactly(t, "ID", ambiguous.Name)
+			assert.Exactly(t, "ID", ambiguous.Path)
+			assert.Exactly(t, []string{"Base.ID", "Meta.ID"}, ambiguous.Candidates)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrAmbiguousField)
+		assert.ErrorContains(t, err, "ambiguous field ID in path ID, it is declared in Base.ID, Meta.ID")
+	}
+
+	if _, err := obj.Get("ID"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrAmbiguousField)
+	}
+
+	if err := obj.Delete("ID"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrAmbiguousField)
+	}
+
+	// Explicit paths through the embedded structs
+	if err := obj.Insert("Base.ID", 1); assert.Nil(t, err) {
+		assert.Exactly(t, 1, data.Base.ID)
+	}
+
+	if err := obj.Insert("Meta.ID", "meta"); assert.Nil(t, err) {
+		assert.Exactly(t, "meta", data.Meta.ID)
+	}
+
+	if value, err := obj.Get("Meta.Version"); assert.Nil(t, err) {
+		assert.Exactly(t, 2, value)
+	}
+
+	if err := obj.Delete("Version"); assert.Nil(t, err) {
+		assert.Exactly(t, 0, data.Meta.Version)
+	}
+
+	// A field declared at a shallower depth hides the promoted one
+	shadow := Shadow{}
+	shadowObj, err := dot.New(&shadow)
+	assert.Nil(t, err)
+
+	if err := shadowObj.Insert("ID", "outer"); assert.Nil(t, err) {
+		assert.Exactly(t, "outer", shadow.ID)
+		assert.Exactly(t, 0, shadow.Base.ID)
+	}
+
+	if err := shadowObj.Insert("Base.ID", 7); assert.Nil(t, err) {
+		assert.Exactly(t, 7, shadow.Base.ID)
+	}
+}
+
+type secret struct {
+	Token string
+}
+
+type hidden struct {
+	Level int
+}
+
+type Fixture struct {
+	Name     string
+	password string
+	counts   map[string]int
+	secret
+	*hidden
+	Others map[string]Fixture
+}
+
+func TestUnexportedFields(t *testing.T) {
+	if _, err := dot.New((*Fixture)(nil)); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNilPointer)
+	}
+
+	data := Fixture{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := obj.Insert("password", "secret"); assert.Error(t, err) {
+		var unexported *dot.UnexportedFieldError
+		if assert.ErrorAs(t, err, &unexported) {
+			assert.Exactly(t, "password", unexported.Field)
+			assert.Exactly(t, "password", unexported.Path)
+			assert.Exactly(t, reflect.TypeOf(data), unexported.Type)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrUnexportedField)
+		assert.ErrorContains(t, err, "cannot access the unexported field password of dot_test.Fixture in path password")
+	}
+
+	if _, err := obj.Get("password"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnexportedField)
+	}
+
+	if err := obj.Delete("counts"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnexportedField)
+	}
+
+	if err := obj.Insert("counts.a", 1); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnexportedField)
+	}
+
+	// Exported fields promoted from the unexported embedded structs are accessible
+	if err := obj.Insert("Token", "token"); assert.Nil(t, err) {
+		assert.Exactly(t, "token", data.secret.Token)
+	}
+
+	// but a nil pointer to such a struct cannot be allocated
+	if err := obj.Insert("Level", 1); assert.Error(t, err) {
+		var unexported *dot.UnexportedFieldError
+		if assert.ErrorAs(t, err, &unexported) {
+			assert.Exactly(t, "hidden", unexported.Field)
+		}
+
+		assert.Nil(t, data.hidden)
+	}
+
+	// The unsafe mode reads and writes unexported fields
+	obj.Unsafe = true
+
+	if err := obj.Insert("password", "secret"); assert.Nil(t, err) {
+		assert.Exactly(t, "secret", data.password)
+	}
+
+	if value, err := obj.Get("password"); assert.Nil(t, err) {
+		assert.Exactly(t, "secret", value)
+	}
+
+	if err := obj.Insert("counts.a", 1); assert.Nil(t, err) {
+		assert.Exactly(t, map[string]int{"a": 1}, data.counts)
+	}
+
+	if err := obj.Insert("Level", 2); assert.Nil(t, err) {
+		assert.Exactly(t, 2, data.hidden.Level)
+	}
+
+	if err := obj.Insert("Others.first.password", "nested"); assert.Nil(t, err) {
+		assert.Exactly(t, "nested", data.Others["first"].password)
+	}
+
+	if value, err := obj.Get("Others.first.password"); assert.Nil(t, err) {
+		assert.Exactly(t, "nested", value)
+	}
+
+	if err := obj.Delete("password"); assert.Nil(t, err) {
+		assert.Exactly(t, "", data.password)
+	}
+}
+
+type Chain struct {
+	Rules    []string
+	Handlers []Info
+	Nested   map[string][]int
+	Limits   [3]int
+	Any      any
+}
+
+func TestSliceShifting(t *testing.T) {
+	data := Chain{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Prepend creates the slice if it does not exist
+	if err := obj.Prepend("Rules", "b"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"b"}, data.Rules)
+	}
+
+	if err := obj.Prepend("Rules", "a"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b"}, data.Rules)
+	}
+
+	// The append token and -1 append the value
+	if err := obj.Insert("Rules.+", "d"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b", "d"}, data.Rules)
+	}
+
+	if err := obj.InsertAt("Rules.2", "c"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b", "c", "d"}, data.Rules)
+	}
+
+	if err := obj.InsertAt("Rules[+]", "f"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b", "c", "d", "f"}, data.Rules)
+	}
+
+	// Negative positions are counted from the end
+	if err := obj.InsertAt("Rules.-1", "e"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b", "c", "d", "e", "f"}, data.Rules)
+	}
+
+	if err := obj.InsertAt("Rules.6", "g"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b", "c", "d", "e", "f", "g"}, data.Rules)
+	}
+
+	if err := obj.InsertAt("Rules.8", "x"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+	}
+
+	if err := obj.InsertAt("Rules.-8", "x"); assert.Error(t, err) {
+		assert.ErrorContains(t, err, "index -8 out of range in path Rules.-8")
+	}
+
+	// Negative indexes replace, read and delete the elements from the end
+	if err := obj.Insert("Rules.-2", "F"); assert.Nil(t, err) {
+		assert.Exactly(t, "F", data.Rules[5])
+	}
+
+	if value, err := obj.Get("Rules.-1"); assert.Nil(t, err) {
+		assert.Exactly(t, "g", value)
+	}
+
+	if err := obj.Delete("Rules.-1"); assert.Nil(t, err) {
+		assert.Exactly(t, []string{"a", "b", "c", "d", "e", "F"}, data.Rules)
+	}
+
+	// Elements of the nested slices
+	if err := obj.InsertAt("Handlers.0.Title", "first"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+	}
+
+	assert.Nil(t, obj.Insert("Handlers.-1.Title", "second"))
+	if err := obj.Prepend("Handlers", Info{Title: "first"}); assert.Nil(t, err) {
+		assert.Exactly(t, "first", data.Handlers[0].Title)
+		assert.Exactly(t, "second", data.Handlers[1].Title)
+	}
+
+	if err := obj.InsertAt("Handlers.1.Title", "middle"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNotSlice)
+		assert.ErrorContains(t, err, "cannot shift the elements in path Handlers.1.Title, it does not end with a slice index")
+	}
+
+	assert.Nil(t, obj.Insert("Nested.odd.-1", 3))
+	if err := obj.Prepend("Nested.odd", 1); assert.Nil(t, err) {
+		assert.Exactly(t, []int{1, 3}, data.Nested["odd"])
+	}
+
+	// Arrays cannot shift their elements
+	if err := obj.InsertAt("Limits.1", 5); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNotSlice)
+		assert.Exactly(t, [3]int{}, data.Limits)
+	}
+
+	if err := obj.InsertAt("", 5); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrInvalidIndex)
+	}
+
+	// Slices stored in interfaces
+	data.Any = []any{"x", "z"}
+	if err := obj.InsertAt("Any.1", "y"); assert.Nil(t, err) {
+		assert.Exactly(t, []any{"x", "y", "z"}, data.Any)
+	}
+}
+
+func TestSliceGrow(t *testing.T) {
+	data := Data{}
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if err := obj.Insert("F.2.Title", "third"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+		assert.Empty(t, data.F)
+	}
+
+	obj.Grow = true
+
+	if err := obj.Insert("F.2.Title", "third"); assert.Nil(t, err) {
+		assert.Len(t, data.F, 3)
+		assert.Exactly(t, Info{}, data.F[0])
+		assert.Exactly(t, "third", data.F[2].Title)
+	}
+
+	if err := obj.Insert("F.0.Title", "first"); assert.Nil(t, err) {
+		assert.Len(t, data.F, 3)
+		assert.Exactly(t, "first", data.F[0].Title)
+	}
+
+	// The slice is not extended if the insertion fails
+	if err := obj.Insert("F.5.Unknown", "value"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+		assert.Len(t, data.F, 3)
+	}
+
+	// Negative indexes do not grow the slice
+	if err := obj.Insert("F.-5.Title", "value"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+	}
+
+	obj.GrowLimit = 10
+	if err := obj.Insert("E.9", 9); assert.Nil(t, err) {
+		assert.Exactly(t, []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 9}, data.E)
+	}
+
+	if err := obj.Insert("E.10", 10); assert.Error(t, err) {
+		var outOfRange *dot.IndexOutOfRangeError
+		if assert.ErrorAs(t, err, &outOfRange) {
+			assert.Exactly(t, 10, outOfRange.Index)
+			assert.Exactly(t, 10, outOfRange.Length)
+		}
+	}
+
+	// Arrays keep their length
+	if err := obj.Insert("G.3", 3); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrIndexOutOfRange)
+	}
+}
+
+type Feature struct {
+	Name    string
+	Enabled bool
+}
+
+type Catalog struct {
+	Features []Feature
+	Parts    map[string]Part
+	Slots    [2]Feature
+	Groups   map[int][]Feature
+	Extra    map[string]any
+}
+
+func TestWildcard(t *testing.T) {
+	data := Catalog{
+		Features: []Feature{{Name: "search", Enabled: true}, {Name: "export", Enabled: true}},
+		Parts: map[string]Part{
+			"b": {Slug: "b", Count: 2},
+			"a": {Slug: "a", Count: 1},
+		},
+		Groups: map[int][]Feature{
+			10: {{Name: "ten"}},
+			2:  {{Name: "two"}, {Name: "three"}},
+		},
+	}
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Insert applies the value to every element
+	if err := obj.Insert("Features.*.Enabled", false); assert.Nil(t, err) {
+		assert.False(t, data.Features[0].Enabled)
+		assert.False(t, data.Features[1].Enabled)
+	}
+
+	if err := obj.Insert("Parts.*.Count", 0); assert.Nil(t, err) {
+		assert.Exactly(t, 0, data.Parts["a"].Count)
+		assert.Exactly(t, "a", data.Parts["a"].Slug)
+		assert.Exactly(t, 0, data.Parts["b"].Count)
+	}
+
+	if err := obj.Insert("Slots[*].Name", "empty"); assert.Nil(t, err) {
+		assert.Exactly(t, "empty", data.Slots[0].Name)
+		assert.Exactly(t, "empty", data.Slots[1].Name)
+	}
+
+	if err := obj.Insert("Groups.*.*.Enabled", true); assert.Nil(t, err) {
+		assert.True(t, data.Groups[2][1].Enabled)
+		assert.True(t, data.Groups[10][0].Enabled)
+	}
+
+	// GetAll returns the values with their concrete paths
+	if matches, err := obj.GetAll("Groups.*.*.Name"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "Groups.2.0.Name", Value: "two"},
+			{Path: "Groups.2.1.Name", Value: "three"},
+			{Path: "Groups.10.0.Name", Value: "ten"},
+		}, matches)
+	}
+
+	if matches, err := obj.GetAll("Parts.*"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "Parts.a", Value: Part{Slug: "a"}},
+			{Path: "Parts.b", Value: Part{Slug: "b"}},
+		}, matches)
+	}
+
+	// A path without wildcards matches a single value
+	if matches, err := obj.GetAll("Features.0.Name"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{{Path: "Features.0.Name", Value: "search"}}, matches)
+	}
+
+	if _, err := obj.GetAll("Unknown.*"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+	}
+
+	// A quoted asterisk is a plain map key
+	if err := obj.Insert(`Parts["*"].Slug`, "star"); assert.Nil(t, err) {
+		assert.Exactly(t, "star", data.Parts["*"].Slug)
+	}
+
+	if value, err := obj.Get(`Parts["*"].Slug`); assert.Nil(t, err) {
+		assert.Exactly(t, "star", value)
+	}
+
+	// Get and Delete work with a single value, so they do not accept the wildcard
+	if _, err := obj.Get("Parts.*.Slug"); assert.Error(t, err) {
+		var pathErr *dot.PathError
+		if assert.ErrorAs(t, err, &pathErr) {
+			assert.Exactly(t, "Parts.*", pathErr.Path)
+			assert.Exactly(t, 1, pathErr.Index)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrWildcard)
+		assert.ErrorContains(t, err, "the path Parts.* can match several values, use GetAll to read them")
+	}
+
+	if _, err := obj.Get("Features.*"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrWildcard)
+	}
+
+	if _, err := obj.GetPath(dot.MustCompile("**.Slug")); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrWildcard)
+	}
+
+	if err := obj.Delete("Parts.*"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrWildcard)
+		assert.Contains(t, data.Parts, "*")
+	}
+
+	if err := obj.DeletePath(dot.MustCompile("Features[?Enabled==false]")); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrWildcard)
+		assert.Len(t, data.Features, 2)
+	}
+
+	if err := obj.Delete(`Parts["*"]`); assert.Nil(t, err) {
+		assert.NotContains(t, data.Parts, "*")
+	}
+
+	data.Parts["*"] = Part{Slug: "star"}
+
+	// Failures are reported per element, the other elements are still changed
+	data.Extra = map[string]any{
+		"first":  map[string]any{"name": "first"},
+		"second": "not an object",
+		"third":  map[string]any{"name": "third"},
+	}
+
+	if err := obj.Insert("Extra.*.name", "renamed"); assert.Error(t, err) {
+		var partial *dot.PartialError
+		if assert.ErrorAs(t, err, &partial) {
+			assert.Exactly(t, "Extra.*.name", partial.Path)
+			if assert.Len(t, partial.Errors, 1) {
+				assert.ErrorContains(t, partial.Errors[0], "unknown path: Extra.second.name")
+			}
+		}
+
+		assert.ErrorIs(t, err, dot.ErrPartial)
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+		assert.Exactly(t, "renamed", data.Extra["first"].(map[string]any)["name"])
+		assert.Exactly(t, "not an object", data.Extra["second"])
+		assert.Exactly(t, "renamed", data.Extra["third"].(map[string]any)["name"])
+	}
+
+	if matches, err := obj.GetAll("Extra.*.name"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrPartial)
+		assert.Exactly(t, []dot.Match{
+			{Path: "Extra.first.name", Value: "renamed"},
+			{Path: "Extra.third.name", Value: "renamed"},
+		}, matches)
+	}
+}
+
+type Ledger struct {
+	Grid   map[[2]int]Part
+	Days   map[time.Weekday]Part
+	Names  map[string]Part
+	Delays map[time.Duration]Part
+	Rates  map[float64]Part
+	Hosts  map[netip.Addr]Part
+	Points map[Point]Part
+	Flags  map[bool]Part
+}
+
+func TestWildcardPaths(t *testing.T) {
+	data := Ledger{
+		Grid:   map[[2]int]Part{{1, 2}: {Slug: "grid"}},
+		Days:   map[time.Weekday]Part{time.Monday: {Slug: "monday"}},
+		Names:  map[string]Part{"*": {Slug: "star"}, "**": {Slug: "stars"}, "+": {Slug: "plus"}, "a.b": {Slug: "dot"}},
+		Delays: map[time.Duration]Part{time.Second: {Slug: "second"}},
+		Rates:  map[float64]Part{1.5: {Slug: "rate"}, -2: {Slug: "negative"}},
+		Hosts:  map[netip.Addr]Part{netip.MustParseAddr("10.0.0.1"): {Slug: "host"}},
+		Points: map[Point]Part{{X: 3, Y: 4}: {Slug: "point"}},
+		Flags:  map[bool]Part{true: {Slug: "flag"}},
+	}
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// The concrete paths of the matches lead back to the same values
+	for _, field := range []string{"Grid", "Days", "Names", "Delays", "Rates", "Hosts", "Points", "Flags"} {
+		matches, err := obj.GetAll(field + ".*.Slug")
+		if !assert.Nil(t, err) || !assert.NotEmpty(t, matches) {
+			continue
+		}
+
+		for _, match := range matches {
+			value, err := obj.Get(match.Path)
+			if assert.Nil(t, err, match.Path) {
+				assert.Exactly(t, match.Value, value, match.Path)
+			}
+		}
+	}
+
+	if matches, err := obj.GetAll("Names.*.Slug"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: `Names["*"].Slug`, Value: "star"},
+			{Path: `Names["**"].Slug`, Value: "stars"},
+			{Path: `Names["+"].Slug`, Value: "plus"},
+			{Path: `Names["a.b"].Slug`, Value: "dot"},
+		}, matches)
+	}
+
+	// The quoted asterisk of the concrete path is not a wildcard
+	if err := obj.Insert(`Names["*"].Count`, 1); assert.Nil(t, err) {
+		assert.Exactly(t, 1, data.Names["*"].Count)
+		assert.Exactly(t, 0, data.Names["a.b"].Count)
+	}
+
+	if matches, err := obj.GetAll("Days.*"); assert.Nil(t, err) {
+		assert.Exactly(t, "Days.1", matches[0].Path)
+	}
+
+	if matches, err := obj.GetAll("Grid.*"); assert.Nil(t, err) {
+		assert.Exactly(t, "Grid.1,2", matches[0].Path)
+	}
+}
+
+type Credential struct {
+	Token string
+}
+
+type Profile struct {
+	Credential
+	Login    string
+	Password string
+	Parent   *Profile
+	Backups  []Credential
+	Devices  map[string]*Credential
+	Extra    any
+}
+
+type Branch struct {
+	Name     string
+	Children []Branch
+}
+
+func TestRecursiveDescent(t *testing.T) {
+	parent := &Profile{Login: "root", Password: "parent"}
+	data := Profile{
+		Credential: Credential{Token: "own"},
+		Login:      "user",
+		Password:   "secret",
+		Parent:     parent,
+		Backups:    []Credential{{Token: "first"}, {Token: "second"}},
+		Devices:    map[string]*Credential{"phone": {Token: "phone"}},
+		Extra:      map[string]any{"Token": "extra", "nested": []any{map[string]any{"Password": "deep"}}},
+	}
+
+	// The pointer graph contains a cycle
+	parent.Parent = &data
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	if matches, err := obj.GetAll("**.Password"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "Password", Value: "secret"},
+			{Path: "Parent.Password", Value: "parent"},
+			{Path: "Extra.nested.0.Password", Value: "deep"},
+		}, matches)
+	}
+
+	if matches, err := obj.GetAll("**.Token"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "Credential.Token", Value: "own"},
+			{Path: "Parent.Credential.Token", Value: ""},
+			{Path: "Backups.0.Token", Value: "first"},
+			{Path: "Backups.1.Token", Value: "second"},
+			{Path: "Devices.phone.Token", Value: "phone"},
+			{Path: "Extra.Token", Value: "extra"},
+		}, matches)
+	}
+
+	// The descent can start anywhere in the path
+	if matches, err := obj.GetAll("Parent.**.Login"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "Parent.Login", Value: "root"},
+			{Path: "Parent.Parent.Login", Value: "user"},
+		}, matches)
+	}
+
+	if err := obj.Insert("**.Password", "redacted"); assert.Nil(t, err) {
+		assert.Exactly(t, "redacted", data.Password)
+		assert.Exactly(t, "redacted", parent.Password)
+		assert.Exactly(t, "redacted", data.Extra.(map[string]any)["nested"].([]any)[0].(map[string]any)["Password"])
+	}
+
+	if err := obj.Insert("**.Token", ""); assert.Nil(t, err) {
+		assert.Exactly(t, "", data.Token)
+		assert.Exactly(t, []Credential{{}, {}}, data.Backups)
+		assert.Exactly(t, "", data.Devices["phone"].Token)
+		assert.Exactly(t, "", data.Extra.(map[string]any)["Token"])
+	}
+
+	// Nothing is created where the rest of the path does not begin
+	if err := obj.Insert("**.Unknown", 1); assert.Nil(t, err) {
+		assert.NotContains(t, data.Extra.(map[string]any), "Unknown")
+	}
+
+	if _, err := obj.GetAll("Login.**"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrSyntax)
+	}
+
+	if _, err := obj.GetAll("**.**.Login"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrSyntax)
+	}
+
+	// The wildcard following the descent matches the elements of every collection
+	tree := Branch{Name: "root", Children: []Branch{{Name: "child", Children: []Branch{{Name: "leaf"}}}}}
+	treeObj, err := dot.New(&tree)
+	assert.Nil(t, err)
+
+	if matches, err := treeObj.GetAll("**.*.Name"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "Children.0.Name", Value: "child"},
+			{Path: "Children.0.Children.0.Name", Value: "leaf"},
+		}, matches)
+	}
+
+	if err := treeObj.Insert("**.*.Name", "redacted"); assert.Nil(t, err) {
+		assert.Exactly(t, "root", tree.Name)
+		assert.Exactly(t, "redacted", tree.Children[0].Name)
+		assert.Exactly(t, "redacted", tree.Children[0].Children[0].Name)
+	}
+
+	if matches, err := treeObj.GetAll("**.*"); assert.Nil(t, err) {
+		assert.Len(t, matches, 2)
+	}
+
+	// The pointers stored in interfaces are followed
+	nested := map[string]any{"u": &Profile{Parent: &Profile{Password: "nested"}}}
+	nestedObj, err := dot.New(&nested)
+	assert.Nil(t, err)
+
+	if matches, err := nestedObj.GetAll("**.Password"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{
+			{Path: "u.Password", Value: ""},
+			{Path: "u.Parent.Password", Value: "nested"},
+		}, matches)
+	}
+
+	// The copies of the map values freed by the garbage collector are not taken for the visited values
+	defer debug.SetGCPercent(debug.SetGCPercent(1))
+	runtime.GC()
+
+	profiles := make(map[int]Profile, 2000)
+	for index := 0; index < 2000; index++ {
+		profiles[index] = Profile{Password: "secret", Backups: []Credential{{Token: "token"}}}
+	}
+
+	profilesObj, err := dot.New(&profiles)
+	assert.Nil(t, err)
+
+	if err := profilesObj.Insert("**.Password", "redacted"); assert.Nil(t, err) {
+		unchanged := 0
+		for _, profile := range profiles {
+			if profile.Password != "redacted" {
+				unchanged++
+			}
+		}
+
+		assert.Zero(t, unchanged)
+	}
+}
+
+type Member struct {
+	ID     int
+	Name   string
+	Active bool
+	Tags   []string
+	Boss   *Member
+}
+
+type Team struct {
+	Members []Member
+	Index   map[string]*Member
+	Raw     []any
+}
+
+func TestFilters(t *testing.T) {
+	data := Team{
+		Members: []Member{
+			{ID: 7, Name: "Ann", Active: true, Tags: []string{"go", "sql"}},
+			{ID: 42, Name: "Bob", Tags: []string{"rust"}},
+			{ID: 50, Name: "Cid", Active: true, Boss: &Member{Name: "Ann"}},
+		},
+		Index: map[string]*Member{"x": {ID: 1, Name: "x.y]z"}, "y": {ID: 2}},
+		Raw:   []any{map[string]any{"id": 1}, map[string]any{"name": "no id"}, map[string]any{"id": 2}},
+	}
+
+	obj, err := dot.New(&data)
+	assert.Nil(t, err)
+
+	// Get returns the first element satisfying the filter
+	if value, err := obj.Get("Members[?ID==42].Name"); assert.Nil(t, err) {
+		assert.Exactly(t, "Bob", value)
+	}
+
+	if value, err := obj.Get("Members[?Active==true].ID"); assert.Nil(t, err) {
+		assert.Exactly(t, 7, value)
+	}
+
+	if value, err := obj.Get(`Members[?Boss.Name=="Ann"].Name`); assert.Nil(t, err) {
+		assert.Exactly(t, "Cid", value)
+	}
+
+	if value, err := obj.Get(`Index[?Name == 'x.y]z'].ID`); assert.Nil(t, err) {
+		assert.Exactly(t, 1, value)
+	}
+
+	if _, err := obj.Get("Members[?ID==1000].Name"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+		assert.ErrorContains(t, err, "unknown path: Members[?ID==1000]")
+	}
+
+	// Insert changes every element satisfying the filter
+	if err := obj.Insert("Members[?ID==42].Name", "Robert"); assert.Nil(t, err) {
+		assert.Exactly(t, "Robert", data.Members[1].Name)
+		assert.Exactly(t, "Ann", data.Members[0].Name)
+	}
+
+	if err := obj.Insert("Members[?ID>10].Active", false); assert.Nil(t, err) {
+		assert.True(t, data.Members[0].Active)
+		assert.False(t, data.Members[2].Active)
+	}
+
+	if err := obj.Insert("Index[?ID!=1].Name", "other"); assert.Nil(t, err) {
+		assert.Exactly(t, "x.y]z", data.Index["x"].Name)
+		assert.Exactly(t, "other", data.Index["y"].Name)
+	}
+
+	if err := obj.Insert("Members[?ID==1000].Name", "nobody"); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrUnknownPath)
+	}
+
+	// GetAll returns the concrete paths of the elements
+	if matches, err := obj.GetAll("Members[?Tags contains go].Name"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{{Path: "Members.0.Name", Value: "Ann"}}, matches)
+	}
+
+	if matches, err := obj.GetAll("Members[?Name>B].ID"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{{Path: "Members.1.ID", Value: 42}, {Path: "Members.2.ID", Value: 50}}, matches)
+	}
+
+	if matches, err := obj.GetAll("Members[?Name contains ob]"); assert.Nil(t, err) {
+		assert.Len(t, matches, 1)
+	}
+
+	// The elements without the compared field do not match
+	if matches, err := obj.GetAll("Raw[?id>1].id"); assert.Nil(t, err) {
+		assert.Exactly(t, []dot.Match{{Path: "Raw.2.id", Value: 2}}, matches)
+	}
+
+	// The literal is parsed into the type of the compared value
+	if _, err := obj.Get("Members[?ID==abc]"); assert.Error(t, err) {
+		var filterErr *dot.FilterError
+		if assert.ErrorAs(t, err, &filterErr) {
+			assert.Exactly(t, "?ID==abc", filterErr.Filter)
+			assert.Exactly(t, reflect.TypeOf(0), filterErr.Type)
+		}
+
+		assert.ErrorIs(t, err, dot.ErrFilter)
+	}
+
+	if _, err := obj.Get("Members[?Active>true]"); assert.ErrorIs(t, err, dot.ErrFilter) {
+		assert.ErrorContains(t, err, "requires a number or a string")
+	}
+
+	// The filter does not address a slice index to shift the elements at
+	if err := obj.InsertAt("Members[?ID==7]", Member{}); assert.Error(t, err) {
+		assert.ErrorIs(t, err, dot.ErrNotSlice)
+		assert.Len(t, data.Members, 3)
+	}
+
+	for _, path := range []string{"Members[?ID]", "Members[?==1]", "Members[?ID==]", `Members[?ID=="1]`} {
+		_, err := obj.Get(path)
+		assert.ErrorIs(t, err, dot.ErrSyntax, path)
+	}
+}
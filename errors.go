@@ -0,0 +1,308 @@
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors to check the kind of failure with errors.Is
+var (
+	ErrNotPointer         = errors.New("expected a pointer")
+	ErrNilPointer         = errors.New("expected a non-nil pointer")
+	ErrSyntax             = errors.New("invalid path syntax")
+	ErrUnknownPath        = errors.New("unknown path")
+	ErrNilInterface       = errors.New("nil interface in path")
+	ErrNotSlice           = errors.New("not a slice index")
+	ErrTypeMismatch       = errors.New("type mismatch")
+	ErrInvalidIndex       = errors.New("invalid index")
+	ErrIndexOutOfRange    = errors.New("index out of range")
+	ErrInvalidKey         = errors.New("invalid map key")
+	ErrUnknownPlaceholder = errors.New("unknown placeholder")
+	ErrParse              = errors.New("cannot parse value")
+	ErrAmbiguousField     = errors.New("ambiguous field")
+	ErrUnexportedField    = errors.New("unexported field")
+	ErrPartial            = errors.New("failed for some of the matches")
+	ErrWildcard           = errors.New("path matches several values")
+	ErrFilter             = errors.New("cannot apply filter")
+	ErrChannelFull        = errors.New("channel is full")
+	ErrChannelClosed      = errors.New("channel is closed")
+	ErrChannelEmpty       = errors.New("channel is empty")
+	ErrNilChannel         = errors.New("channel is nil")
+	ErrNotChannel         = errors.New("not a channel to receive from")
+)
+
+// SyntaxError is returned when the path cannot be parsed
+type SyntaxError struct {
+	Path     string // Path is the path as it was written
+	Position int    // Position is the byte offset in the path where the error was found
+	Reason   string // Reason describes what is wrong
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf(`invalid path "%s": %s at position %d`, e.Path, e.Reason, e.Position)
+}
+
+func (e *SyntaxError) Is(target error) bool {
+	return target == ErrSyntax
+}
+
+// PathError is returned when the path cannot be followed any further:
+// there is no such field or key (ErrUnknownPath), an empty interface
+// gives no type to continue with (ErrNilInterface), the path given to InsertAt
+// or Prepend does not end with the index of a slice (ErrNotSlice), or the path given to Get or Delete
+// contains a wildcard, a recursive descent or, for Delete, a filter matching several values (ErrWildcard)
+type PathError struct {
+	Path    string // Path is the path up to the segment that could not be followed
+	Segment string // Segment is the segment that could not be followed
+	Index   int    // Index is the position of the segment in the path
+	Err     error  // Err is ErrUnknownPath, ErrNilInterface, ErrNotSlice or ErrWildcard
+}
+
+func (e *PathError) Error() string {
+	switch e.Err {
+	case ErrNilInterface:
+		return fmt.Sprintf(errInterface, e.Path)
+	case ErrNotSlice:
+		return fmt.Sprintf(errNotSlice, e.Path)
+	case ErrWildcard:
+		return fmt.Sprintf(errWildcard, e.Path)
+	default:
+		return fmt.Sprintf(errUnknownPath, e.Path)
+	}
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// AmbiguousFieldError is returned when the name in the path refers to fields of several embedded structs
+// declared at the same depth, the way Go rejects such selectors. The field can still be reached
+// through the embedded struct, e.g. Base.ID instead of ID
+type AmbiguousFieldError struct {
+	Path       string   // Path is the path up to the ambiguous name
+	Name       string   // Name is the ambiguous name
+	Candidates []string // Candidates are the paths to the fields sharing the name, e.g. Base.ID and Meta.ID
+}
+
+func (e *AmbiguousFieldError) Error() string {
+	return fmt.Sprintf(
+		"ambiguous field %s in path %s, it is declared in %s", e.Name, e.Path, strings.Join(e.Candidates, ", "),
+	)
+}
+
+func (e *AmbiguousFieldError) Is(target error) bool {
+	return target == ErrAmbiguousField
+}
+
+// UnexportedFieldError is returned when the path leads through an unexported struct field,
+// which can be read and written only in the unsafe mode
+type UnexportedFieldError struct {
+	Path  string       // Path is the path up to the field
+	Field string       // Field is the name of the unexported field
+	Type  reflect.Type // Type is the struct type declaring the field
+}
+
+func (e *UnexportedFieldError) Error() string {
+	return fmt.Sprintf("cannot access the unexported field %s of %s in path %s", e.Field, e.Type, e.Path)
+}
+
+func (e *UnexportedFieldError) Is(target error) bool {
+	return target == ErrUnexportedField
+}
+
+// TypeMismatchError is returned when the type of the value
+// does not match the type expected at the end of the path
+type TypeMismatchError struct {
+	Path     string       // Path is the path where the mismatch was found
+	Expected reflect.Type // Expected is the type required by the path
+	Got      reflect.Type // Got is the type of the value provided, nil for an untyped nil
+	Scenario Scenario     // Scenario is the kind of container the value was intended for
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf(errMsg[e.Scenario], typeName(e.Expected), typeName(e.Got), e.Path)
+}
+
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// InvalidIndexError is returned when the segment of the path is not a valid slice or array index
+type InvalidIndexError struct {
+	Path  string       // Path is the path up to the invalid index
+	Value string       // Value is the segment that was expected to be an index
+	Kind  reflect.Kind // Kind is either reflect.Slice or reflect.Array
+}
+
+func (e *InvalidIndexError) Error() string {
+	if e.Kind == reflect.Array {
+		return fmt.Sprintf(`invalid value "%s" as an array index`, e.Value)
+	}
+
+	return fmt.Sprintf(`invalid value "%s" as a slice index`, e.Value)
+}
+
+func (e *InvalidIndexError) Is(target error) bool {
+	return target == ErrInvalidIndex
+}
+
+// IndexOutOfRangeError is returned when the index is outside the slice or the array
+type IndexOutOfRangeError struct {
+	Path   string       // Path is the path up to the index
+	Index  int          // Index is the index from the path
+	Length int          // Length is the length of the slice or the array
+	Type   reflect.Type // Type is the type of the slice or the array
+}
+
+func (e *IndexOutOfRangeError) Error() string {
+	if e.Type != nil && e.Type.Kind() == reflect.Array {
+		return fmt.Sprintf("index %d out of range in path %s of type %s", e.Index, e.Path, e.Type)
+	}
+
+	return fmt.Sprintf("index %d out of range in path %s", e.Index, e.Path)
+}
+
+func (e *IndexOutOfRangeError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+// InvalidKeyError is returned when the segment cannot be converted into the key of the map
+type InvalidKeyError struct {
+	Path string       // Path is the path up to the key
+	Key  string       // Key is the segment that was expected to be a map key
+	Type reflect.Type // Type is the type of the map key
+	Err  error        // Err is the error returned by the parser of the key
+}
+
+func (e *InvalidKeyError) Error() string {
+	return fmt.Sprintf(`the map key has an invalid key-value "%s" in path "%s" of type %s`, e.Key, e.Path, e.Type)
+}
+
+func (e *InvalidKeyError) Unwrap() error {
+	return e.Err
+}
+
+func (e *InvalidKeyError) Is(target error) bool {
+	return target == ErrInvalidKey
+}
+
+// UnknownPlaceholderError is returned when the map key cannot be written in the path
+// and no placeholder was registered for the segment with Replace
+type UnknownPlaceholderError struct {
+	Path string       // Path is the path up to the key
+	Key  string       // Key is the segment that was expected to be a placeholder
+	Type reflect.Type // Type is the type of the map key
+}
+
+func (e *UnknownPlaceholderError) Error() string {
+	return fmt.Sprintf(`unknown placeholder of type %s as map key in path "%s"`, e.Type, e.Path)
+}
+
+func (e *UnknownPlaceholderError) Is(target error) bool {
+	return target == ErrUnknownPlaceholder
+}
+
+// ParseError is returned when the string inserted with InsertString
+// cannot be parsed into the type found at the end of the path
+type ParseError struct {
+	Path  string       // Path is the path where the value was going to be inserted
+	Value string       // Value is the string that could not be parsed
+	Type  reflect.Type // Type is the type in the path
+	Err   error        // Err is the error returned by the parser
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf(`cannot parse "%s" as %s in path %s: %v`, e.Value, e.Type, e.Path, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// FilterError is returned when the filter segment, e.g. Users[?ID==42], cannot be applied to an element:
+// the literal cannot be parsed into the type of the compared value or the operator does not support the type
+type FilterError struct {
+	Path   string       // Path is the path up to the filter
+	Filter string       // Filter is the filter as it was written, without the brackets
+	Type   reflect.Type // Type is the type of the compared value
+	Err    error        // Err is the error of the parser or of the operator
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("cannot apply the filter [%s] to type %s in path %s: %v", e.Filter, e.Type, e.Path, e.Err)
+}
+
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FilterError) Is(target error) bool {
+	return target == ErrFilter
+}
+
+// ChannelError is returned when the value cannot be sent into or received from the channel in the path:
+// the channel is full or empty in the non-blocking mode (ErrChannelFull, ErrChannelEmpty), the channel
+// is closed (ErrChannelClosed), the context was cancelled or its deadline expired (the error of the context),
+// or there is no channel to receive from (ErrNilChannel, ErrNotChannel)
+type ChannelError struct {
+	Path string       // Path is the path to the channel
+	Type reflect.Type // Type is the type of the channel, or of the value found in place of the channel
+	Op   string       // Op is either "send" or "receive"
+	Err  error        // Err is one of the sentinel errors above or the error of the context
+}
+
+func (e *ChannelError) Error() string {
+	if e.Op == receiveOp {
+		return fmt.Sprintf("cannot receive a value from type %s in path %s: %v", e.Type, e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("cannot send a value into the channel of type %s in path %s: %v", e.Type, e.Path, e.Err)
+}
+
+func (e *ChannelError) Unwrap() error {
+	return e.Err
+}
+
+// PartialError is returned when the path with wildcards or the recursive descent cannot be followed
+// for some of the elements it matches, the operation is still performed for all the other elements
+type PartialError struct {
+	Path   string  // Path is the path with wildcards
+	Errors []error // Errors are the errors of the elements, each one reporting the concrete path
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("path %s failed for %d of the matches: %v", e.Path, len(e.Errors), e.Errors[0])
+}
+
+func (e *PartialError) Unwrap() []error {
+	return e.Errors
+}
+
+func (e *PartialError) Is(target error) bool {
+	return target == ErrPartial
+}
+
+// unknownPath reports the last segment of the traversed path as one that does not exist
+func unknownPath(currentPath trail) error {
+	return &PathError{
+		Path:    currentPath.String(),
+		Segment: currentPath.segments[currentPath.length-1].name,
+		Index:   currentPath.length - 1,
+		Err:     ErrUnknownPath,
+	}
+}
+
+// typeName returns the name of the type, including the untyped nil
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "nil"
+	}
+
+	return t.String()
+}
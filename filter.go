@@ -0,0 +1,242 @@
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Operators of the filter segments, e.g. Users[?ID==42]. The longer ones come first,
+// so that the operator is not mistaken for the one it begins with
+var operators = []string{"==", "!=", "<", ">", " contains "}
+
+// filter is the predicate of the filter segment selecting the elements of a collection
+type filter struct {
+	field    []segment // field is the path to the compared value inside the element, e.g. ID or Address.City
+	operator string    // operator is one of the operators above, contains without the spaces around it
+	value    string    // value is the literal parsed into the type of the compared value
+}
+
+// parseFilter reads the filter written in brackets, e.g. [?ID==42] or [?Name=="Bob"].
+// The position points to the question mark
+func parseFilter(path string, start, position int) (segment, int, error) {
+	// The closing bracket is searched outside of the quoted value
+	end := -1
+	for index, quote := position, byte(0); index < len(path) && end == -1; index++ {
+		switch {
+		case quote != 0 && path[index] == '\\':
+			index++
+		case quote != 0 && path[index] == quote:
+			quote = 0
+		case quote != 0:
+		case path[index] == '"' || path[index] == '\'':
+			quote = path[index]
+		case path[index] == ']':
+			end = index
+		}
+	}
+
+	if end == -1 {
+		return segment{}, 0, pathError(path, start, "missing closing bracket")
+	}
+
+	body := path[position+1 : end]
+	for index := 0; index < len(body); index++ {
+		for _, operator := range operators {
+			if !strings.HasPrefix(body[index:], operator) {
+				continue
+			}
+
+			field, err := parsePath(strings.TrimSpace(body[:index]))
+			if err != nil || len(field) == 0 {
+				return segment{}, 0, pathError(path, position+1, "invalid field in the filter")
+			}
+
+			value, err := filterValue(strings.TrimSpace(body[index+len(operator):]))
+			if err != nil {
+				return segment{}, 0, pathError(path, position+1+index+len(operator), err.Error())
+			}
+
+			predicate := &filter{field: field, operator: strings.TrimSpace(operator), value: value}
+
+			return segment{name: path[position:end], filter: predicate}, end + 1, nil
+		}
+	}
+
+	return segment{}, 0, pathError(path, position+1, "missing operator in the filter")
+}
+
+// filterValue removes the quotes and the escapes from the quoted value of the filter
+func filterValue(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("missing value in the filter")
+	}
+
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		return value, nil
+	}
+
+	if len(value) < 2 || value[len(value)-1] != quote {
+		return "", errors.New("unterminated quoted value in the filter")
+	}
+
+	var unquoted strings.Builder
+	for index := 1; index < len(value)-1; index++ {
+		if value[index] == '\\' && index+1 < len(value)-1 {
+			index++
+		}
+
+		unquoted.WriteByte(value[index])
+	}
+
+	return unquoted.String(), nil
+}
+
+// matches reports whether the element satisfies the filter. An element without the compared field
+// does not match, while a literal that cannot be parsed into the type of the field is reported
+func (d *Dot) matches(elem reflect.Value, part segment, currentPath trail) (bool, error) {
+	predicate := part.filter
+
+	value, err := d.get(elem, trail{segments: predicate.field}, predicate.field)
+	if err != nil {
+		if errors.Is(err, ErrUnknownPath) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	for (value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer) && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	// A nil pointer or interface is different from any literal
+	if value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		return predicate.operator == "!=", nil
+	}
+
+	failed := func(err error) (bool, error) {
+		return false, &FilterError{Path: currentPath.String(), Filter: part.name, Type: value.Type(), Err: err}
+	}
+
+	switch predicate.operator {
+	case "contains":
+		found, err := d.contains(value, predicate.value)
+		if err != nil {
+			return failed(err)
+		}
+
+		return found, nil
+	case "==", "!=":
+		if !value.Type().Comparable() {
+			return failed(fmt.Errorf("the operator %s cannot compare values of this type", predicate.operator))
+		}
+
+		literal, err := d.parseKey(value.Type(), predicate.value)
+		if err != nil {
+			return failed(err)
+		}
+
+		return value.Equal(literal) == (predicate.operator == "=="), nil
+	default:
+		literal, err := d.parseKey(value.Type(), predicate.value)
+		if err != nil {
+			return failed(err)
+		}
+
+		order, ok := compare(value, literal)
+		if !ok {
+			return failed(fmt.Errorf("the operator %s requires a number or a string", predicate.operator))
+		}
+
+		return (predicate.operator == "<" && order < 0) || (predicate.operator == ">" && order > 0), nil
+	}
+}
+
+// contains reports whether the string contains the text, the slice or the array contains the element
+// or the map contains the key parsed from the text
+func (d *Dot) contains(value reflect.Value, text string) (bool, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.Contains(value.String(), text), nil
+	case reflect.Map:
+		key, err := d.parseKey(value.Type().Key(), text)
+		if err != nil {
+			return false, err
+		}
+
+		return value.MapIndex(key).IsValid(), nil
+	case reflect.Slice, reflect.Array:
+		if !value.Type().Elem().Comparable() {
+			return false, errors.New("the operator contains cannot compare the elements of this type")
+		}
+
+		literal, err := d.parseKey(value.Type().Elem(), text)
+		if err != nil {
+			return false, err
+		}
+
+		for index := 0; index < value.Len(); index++ {
+			if value.Index(index).Equal(literal) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	default:
+		return false, errors.New("the operator contains requires a string, a slice, an array or a map")
+	}
+}
+
+// compare orders the numbers and the strings of the same type, it reports false for other types
+func compare(a, b reflect.Value) (int, bool) {
+	switch {
+	case isInt(a.Kind()):
+		return order(a.Int(), b.Int()), true
+	case isUint(a.Kind()):
+		return order(a.Uint(), b.Uint()), true
+	case isFloat(a.Kind()):
+		return order(a.Float(), b.Float()), true
+	case a.Kind() == reflect.String:
+		return order(a.String(), b.String()), true
+	default:
+		return 0, false
+	}
+}
+
+// order compares two ordered values
+func order[T int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// selected returns the elements of the collection satisfying the filter of the segment,
+// or all of them for the wildcard
+func (d *Dot) selected(innerObj reflect.Value, part segment, currentPath trail) ([]element, error) {
+	all := d.elements(innerObj)
+	if part.filter == nil {
+		return all, nil
+	}
+
+	result := make([]element, 0, len(all))
+	for _, elem := range all {
+		ok, err := d.matches(elem.value, part, currentPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			result = append(result, elem)
+		}
+	}
+
+	return result, nil
+}
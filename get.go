@@ -0,0 +1,110 @@
+package dot
+
+import "reflect"
+
+// Get returns the value located at the specified path separated by dots.
+// Unlike Insert, it never creates missing maps, slices or channels on the way
+// and leaves the object untouched
+func (d *Dot) Get(path string) (any, error) {
+	compiled, err := compile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetPath(compiled)
+}
+
+// GetPath returns the value located at the precompiled path
+func (d *Dot) GetPath(path *Path) (any, error) {
+	// The filter selects the first element satisfying it, the other segments matching several values
+	// can only be read with GetAll
+	if err := several(path.segments, false); err != nil {
+		return nil, err
+	}
+
+	d.readLock()
+	defer d.readUnlock()
+
+	value, err := d.get(d.Object, trail{segments: path.segments}, path.segments)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.Interface(), nil
+}
+
+// get walks along the path and returns the value found at its end
+func (d *Dot) get(innerObj reflect.Value, previousPath trail, parts []segment) (reflect.Value, error) {
+	for index, part := range parts {
+		// Preparing the path to the current segment for error messages
+		currentPath := previousPath.next(index + 1)
+
+		// The path continues inside the value stored in the interface or referenced by the pointer,
+		// a nil one has nothing inside and ends up as an unknown path
+		for (innerObj.Kind() == reflect.Interface || innerObj.Kind() == reflect.Pointer) && !innerObj.IsNil() {
+			innerObj = innerObj.Elem()
+		}
+
+		// The filter selects the first element satisfying it
+		if part.filter != nil {
+			elem, err := d.first(innerObj, part, currentPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			innerObj = elem
+			continue
+		}
+
+		switch innerObj.Kind() {
+		case reflect.Map:
+			key, err := d.prepareKey(innerObj, part, currentPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			innerObj = innerObj.MapIndex(key)
+		case reflect.Slice, reflect.Array:
+			elem, err := elementIndex(innerObj, part.name, currentPath)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			innerObj = innerObj.Index(elem)
+		case reflect.Struct:
+			field, err := d.field(innerObj, part, currentPath, false)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			innerObj = field
+		default:
+			// Primitive types, channels, nil pointers and empty interfaces cannot be traversed any further
+			return reflect.Value{}, unknownPath(currentPath)
+		}
+
+		if !innerObj.IsValid() {
+			return reflect.Value{}, unknownPath(currentPath)
+		}
+	}
+
+	return innerObj, nil
+}
+
+// first returns the first element of the collection satisfying the filter
+func (d *Dot) first(innerObj reflect.Value, part segment, currentPath trail) (reflect.Value, error) {
+	if !collection(innerObj.Kind()) {
+		return reflect.Value{}, unknownPath(currentPath)
+	}
+
+	found, err := d.selected(innerObj, part, currentPath)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if len(found) == 0 {
+		return reflect.Value{}, unknownPath(currentPath)
+	}
+
+	return found[0].value, nil
+}
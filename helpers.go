@@ -1,13 +1,96 @@
 package dot
 
-import "strings"
+import (
+	"reflect"
+	"strconv"
+)
 
-// preparePath merges the path that has already been traversed with that to be traversed
-// e.g. "old.path" + ["remaining", "path"] => "old.path.remaining.path"
-func preparePath(previousPath string, parts []string) string {
-	if previousPath != "" {
-		parts = append([]string{previousPath}, parts...)
+// elementIndex converts the path segment into the index of an existing element of a slice or an array.
+// Negative indexes of slices are counted from the end, e.g. -2 is the second to last element
+func elementIndex(innerObj reflect.Value, value string, currentPath trail) (int, error) {
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &InvalidIndexError{Path: currentPath.String(), Value: value, Kind: innerObj.Kind()}
 	}
 
-	return strings.Join(parts, ".")
+	position := index
+	if position < 0 && innerObj.Kind() == reflect.Slice {
+		position += innerObj.Len()
+	}
+
+	if innerObj.Len() <= position || position < 0 {
+		return 0, outOfRange(innerObj, index, currentPath)
+	}
+
+	return position, nil
+}
+
+// outOfRange reports the index outside the slice or the array
+func outOfRange(innerObj reflect.Value, index int, currentPath trail) error {
+	return &IndexOutOfRangeError{
+		Path:   currentPath.String(),
+		Index:  index,
+		Length: innerObj.Len(),
+		Type:   innerObj.Type(),
+	}
+}
+
+// nillable reports whether the value of this kind can be nil
+func nillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// indirect dereferences the pointers, allocating the nil ones on the way down
+func indirect(innerObj reflect.Value) reflect.Value {
+	for innerObj.Kind() == reflect.Pointer {
+		if innerObj.IsNil() {
+			innerObj.Set(reflect.New(innerObj.Type().Elem()))
+		}
+
+		innerObj = innerObj.Elem()
+	}
+
+	return innerObj
+}
+
+// baseKind returns the kind of the type the pointers of the type finally refer to
+func baseKind(typ reflect.Type) reflect.Kind {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ.Kind()
+}
+
+// lock locks the object for writing if the Dot is synchronized
+func (d *Dot) lock() {
+	if d.mu != nil {
+		d.mu.Lock()
+	}
+}
+
+// unlock undoes lock
+func (d *Dot) unlock() {
+	if d.mu != nil {
+		d.mu.Unlock()
+	}
+}
+
+// readLock locks the object for reading if the Dot is synchronized
+func (d *Dot) readLock() {
+	if d.mu != nil {
+		d.mu.RLock()
+	}
+}
+
+// readUnlock undoes readLock
+func (d *Dot) readUnlock() {
+	if d.mu != nil {
+		d.mu.RUnlock()
+	}
 }
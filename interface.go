@@ -0,0 +1,58 @@
+package dot
+
+import "reflect"
+
+// Types of containers created in nil interfaces
+var (
+	mapOfAny   = reflect.TypeOf(map[string]any{})
+	sliceOfAny = reflect.TypeOf([]any{})
+)
+
+// inInterface inserts the value into the data stored in the interface
+func (d *Dot) inInterface(
+	op *insertion, innerObj reflect.Value, previousPath trail, parts []segment, content any, source Scenario,
+) error {
+	value, err := d.interfaceValue(innerObj, previousPath, parts[0].name)
+	if err != nil {
+		return err
+	}
+
+	// The value stored in the interface cannot be changed in place,
+	// so it is copied, changed and stored back
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+
+	if err := d.insert(op, copied, previousPath, parts, content, source); err != nil {
+		return err
+	}
+
+	innerObj.Set(copied)
+
+	return nil
+}
+
+// interfaceValue returns the value stored in the interface or creates a container for a nil interface
+func (d *Dot) interfaceValue(innerObj reflect.Value, previousPath trail, key string) (reflect.Value, error) {
+	if !innerObj.IsNil() {
+		return innerObj.Elem(), nil
+	}
+
+	// The interface is reported together with the segment that cannot be followed
+	nilInterface := &PathError{Path: previousPath.String(), Segment: key, Index: previousPath.length, Err: ErrNilInterface}
+
+	if !d.AutoCreate {
+		return reflect.Value{}, nilInterface
+	}
+
+	// Index -1 and the append token append to a slice, any other segment is a key of an object
+	container := mapOfAny
+	if key == "-1" || key == appendToken {
+		container = sliceOfAny
+	}
+
+	if !container.AssignableTo(innerObj.Type()) {
+		return reflect.Value{}, nilInterface
+	}
+
+	return reflect.Zero(container), nil
+}
@@ -1,6 +1,7 @@
 package dot
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
 	"reflect"
@@ -8,6 +9,9 @@ import (
 	"strings"
 )
 
+// errUnknownType is returned by parseType for types that are not primitive
+var errUnknownType = errors.New("unknown type")
+
 // parseType checks if the key does not match one of the primitive types
 func parseType(kind reflect.Kind, value string) (any, error) {
 	switch kind {
@@ -16,7 +20,8 @@ func parseType(kind reflect.Kind, value string) (any, error) {
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return strconv.ParseUint(value, 10, 64)
 	case reflect.Float32, reflect.Float64:
-		// This is a forced measure, as the path to the value is divided by a dot
+		// A comma is still accepted in place of the dot for the plain dotted syntax,
+		// although such keys can now be written in brackets, e.g. ["5.2"]
 		newValue := strings.ReplaceAll(value, ",", ".")
 		return strconv.ParseFloat(newValue, 64)
 	case reflect.Complex64, reflect.Complex128:
@@ -26,72 +31,229 @@ func parseType(kind reflect.Kind, value string) (any, error) {
 	case reflect.String, reflect.Interface:
 		return value, nil
 	default:
-		return nil, errors.New("unknown type")
+		return nil, errUnknownType
 	}
 }
 
 // keyValue prepares the value of the map key itself
-func (d *Dot) keyValue(key reflect.Value, value string, currentPath string) (any, error) {
-	// Trying to find the appropriate key in placeholders
+func (d *Dot) keyValue(keyType reflect.Type, value string, currentPath trail) (reflect.Value, error) {
+	// Placeholders take precedence over any other way of writing the key
 	if placeholder, ok := d.Placeholders[value]; ok {
 		refPlaceholder := reflect.ValueOf(placeholder)
-		if refPlaceholder.Type() != key.Type() {
-			return nil, fmt.Errorf(
-				`the map key type is %s you cannot use the placeholder of type %s in path "%s"`,
-				key.Type(), refPlaceholder.Type(), currentPath,
-			)
+		if refPlaceholder.Type() != keyType {
+			return reflect.Value{}, &TypeMismatchError{
+				Path:     currentPath.String(),
+				Expected: keyType,
+				Got:      refPlaceholder.Type(),
+				Scenario: Key,
+			}
 		}
 
-		return placeholder, nil
+		return refPlaceholder, nil
 	}
 
-	// Trying to catch a primitive key type
-	result, err := parseType(key.Kind(), value)
+	key, err := d.parseKey(keyType, value)
+	if err != nil {
+		// If the key cannot be written in the path at all, it means there is no key placeholder
+		if errors.Is(err, errUnknownType) {
+			return reflect.Value{}, &UnknownPlaceholderError{Path: currentPath.String(), Key: value, Type: keyType}
+		}
+
+		return reflect.Value{}, &InvalidKeyError{Path: currentPath.String(), Key: value, Type: keyType, Err: err}
+	}
 
+	return key, nil
+}
+
+// parseKey converts the segment into the key of the type. The key is parsed by the encoding.TextUnmarshaler
+// of the type, by the parser registered for the type, as a primitive type and finally as a list
+// of comma-separated elements of an array or fields of a struct, e.g. Matrix[1,2]
+func (d *Dot) parseKey(keyType reflect.Type, value string) (reflect.Value, error) {
+	decoded, ok, err := d.decode(keyType, value)
 	if err != nil {
-		// It was not possible to convert the value into a primitive map key type
-		if strings.Contains(err.Error(), "strconv") {
-			return nil, fmt.Errorf(
-				`the map key has an invalid key-value "%s" in path "%s" of type %s`,
-				value, currentPath, key.Type(),
-			)
+		return reflect.Value{}, err
+	}
+
+	if ok {
+		if !decoded.IsValid() || decoded.Kind() != keyType.Kind() || !decoded.Type().ConvertibleTo(keyType) {
+			return reflect.Value{}, fmt.Errorf("the parser returned a value of type %s", typeName(decoded.Type()))
 		}
 
-		// If no primitive type was found, it means there is no key placeholder
-		return nil, fmt.Errorf(
-			`unknown placeholder of type %s as map key in path "%s"`,
-			key.Type(), currentPath,
-		)
+		return decoded.Convert(keyType), nil
+	}
+
+	switch keyType.Kind() {
+	case reflect.Array:
+		return d.arrayKey(keyType, value)
+	case reflect.Struct:
+		return d.structKey(keyType, value)
+	}
+
+	// Trying to catch a primitive key type
+	result, err := parseType(keyType.Kind(), value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Convert the type so that it exactly matches the required type
+	key := reflect.ValueOf(result)
+	if !key.Type().ConvertibleTo(keyType) {
+		return reflect.Value{}, errUnknownType
 	}
 
-	return result, nil
+	return key.Convert(keyType), nil
+}
+
+// formatKey writes the key in the way parseKey reads it back: the types parsed by their
+// encoding.TextUnmarshaler are written with encoding.TextMarshaler, the types parsed by the registered
+// parsers with their String method, primitive types with strconv, and arrays and structs as comma-separated lists
+func (d *Dot) formatKey(key reflect.Value) string {
+	keyType := key.Type()
+
+	if reflect.PointerTo(keyType).Implements(textUnmarshaler) && keyType.Implements(textMarshaler) {
+		if text, err := key.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	if _, ok := d.Parsers[keyType]; ok && keyType.Implements(stringer) {
+		return key.Interface().(fmt.Stringer).String()
+	}
+
+	switch {
+	case isInt(key.Kind()):
+		return strconv.FormatInt(key.Int(), 10)
+	case isUint(key.Kind()):
+		return strconv.FormatUint(key.Uint(), 10)
+	case isFloat(key.Kind()):
+		return strconv.FormatFloat(key.Float(), 'g', -1, keyType.Bits())
+	}
+
+	switch key.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(key.Complex(), 'g', -1, keyType.Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(key.Bool())
+	case reflect.String:
+		return key.String()
+	case reflect.Interface:
+		return d.formatKey(key.Elem())
+	case reflect.Array:
+		elements := make([]string, key.Len())
+		for index := range elements {
+			elements[index] = d.formatKey(key.Index(index))
+		}
+
+		return strings.Join(elements, ",")
+	case reflect.Struct:
+		fields := make([]string, key.NumField())
+		for index := range fields {
+			fields[index] = d.formatKey(key.Field(index))
+		}
+
+		return strings.Join(fields, ",")
+	default:
+		return fmt.Sprint(key)
+	}
+}
+
+// arrayKey parses the comma-separated elements of the array key
+func (d *Dot) arrayKey(keyType reflect.Type, value string) (reflect.Value, error) {
+	elements := strings.Split(value, ",")
+
+	// A segment of a different shape is taken as the name of a missing placeholder
+	if len(elements) != keyType.Len() {
+		return reflect.Value{}, errUnknownType
+	}
+
+	key := reflect.New(keyType).Elem()
+	for index, element := range elements {
+		elem, err := d.parseKey(keyType.Elem(), strings.TrimSpace(element))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		key.Index(index).Set(elem)
+	}
+
+	return key, nil
+}
+
+// structKey parses the comma-separated values of the struct key fields in the order of declaration
+func (d *Dot) structKey(keyType reflect.Type, value string) (reflect.Value, error) {
+	fields := strings.Split(value, ",")
+
+	// A segment of a different shape is taken as the name of a missing placeholder
+	if len(fields) != keyType.NumField() {
+		return reflect.Value{}, errUnknownType
+	}
+
+	key := reflect.New(keyType).Elem()
+	for index, field := range fields {
+		// Unexported fields cannot be set, such keys require a placeholder
+		if !keyType.Field(index).IsExported() {
+			return reflect.Value{}, errUnknownType
+		}
+
+		elem, err := d.parseKey(keyType.Field(index).Type, strings.TrimSpace(field))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		key.Field(index).Set(elem)
+	}
+
+	return key, nil
+}
+
+// parsedKey reports whether the key parsed from the segment depends on the parsers of the Dot,
+// such keys differ from one Dot to another and are not cached in the segment
+func (d *Dot) parsedKey(keyType reflect.Type) bool {
+	if _, ok := d.Parsers[keyType]; ok {
+		return true
+	}
+
+	switch keyType.Kind() {
+	case reflect.Array:
+		return d.parsedKey(keyType.Elem())
+	case reflect.Struct:
+		for index := 0; index < keyType.NumField(); index++ {
+			if d.parsedKey(keyType.Field(index).Type) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // prepareKey creates a key of the appropriate type for the map
-func (d *Dot) prepareKey(innerObj reflect.Value, value string, currentPath string) (reflect.Value, error) {
-	thisMap := reflect.TypeOf(innerObj.Interface())
-	key := reflect.New(thisMap.Key()).Elem()
+func (d *Dot) prepareKey(innerObj reflect.Value, part segment, currentPath trail) (reflect.Value, error) {
+	thisMap := innerObj.Type()
+
+	// Placeholders can be replaced at any time and parsers differ between
+	// the Dots sharing the path, so only the keys parsed as is are taken from the cache
+	_, isPlaceholder := d.Placeholders[part.name]
+	cacheable := !isPlaceholder && !d.parsedKey(thisMap.Key())
+	if cached := part.cached(thisMap); cached != nil && cacheable {
+		return cached.key, nil
+	}
 
 	// Prepare the contents of the key to match its type
-	content, err := d.keyValue(key, value, currentPath)
+	key, err := d.keyValue(thisMap.Key(), part.name, currentPath)
 	if err != nil {
 		return reflect.Value{}, err
 	}
 
-	// Convert the type so that it exactly matches the required type
-	keyValue := reflect.ValueOf(content)
-	if keyValue.Type().ConvertibleTo(key.Type()) {
-		keyValue = keyValue.Convert(key.Type())
+	if cacheable {
+		part.remember(&resolved{typ: thisMap, key: key})
 	}
 
-	// Inserting the key value
-	key.Set(keyValue)
-
 	return key, nil
 }
 
 // inMap inserts a value into the map along the indicated path
-func (d *Dot) inMap(innerObj reflect.Value, currentPath string, parts []string) error {
+func (d *Dot) inMap(op *insertion, innerObj reflect.Value, currentPath trail, parts []segment, content any) error {
 	// Create a map if one does not exist
 	if innerObj.IsNil() {
 		innerObj.Set(reflect.MakeMap(innerObj.Type()))
@@ -107,8 +269,14 @@ func (d *Dot) inMap(innerObj reflect.Value, currentPath string, parts []string)
 	// Initialise the value of the corresponding map type
 	value := reflect.New(innerObj.Type().Elem()).Elem()
 
+	// Map values cannot be changed in place, so the insertion starts
+	// from a copy of the existing value which is written back afterwards
+	if existing := innerObj.MapIndex(key); existing.IsValid() {
+		value.Set(existing)
+	}
+
 	// Insert the value recursively into the variable we just created
-	if err := d.insert(value, currentPath, parts[1:], Map); err != nil {
+	if err := d.insert(op, value, currentPath, parts[1:], content, Map); err != nil {
 		return err
 	}
 
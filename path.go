@@ -0,0 +1,288 @@
+package dot
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Path is a parsed path that can be used any number of times without parsing it again.
+// A Path is immutable and can be shared between goroutines
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Compile parses the path, so that syntax errors are reported once, before the path is used
+func Compile(path string) (*Path, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each segment keeps the result of its last resolution, as do the fields compared by the filters
+	for index := range segments {
+		segments[index].cache = new(atomic.Pointer[resolutions])
+
+		if predicate := segments[index].filter; predicate != nil {
+			for position := range predicate.field {
+				predicate.field[position].cache = new(atomic.Pointer[resolutions])
+			}
+		}
+	}
+
+	return &Path{raw: path, segments: segments}, nil
+}
+
+// MustCompile is like Compile but panics if the path cannot be parsed.
+// It simplifies the initialisation of global variables holding paths
+func MustCompile(path string) *Path {
+	compiled, err := Compile(path)
+	if err != nil {
+		panic(err)
+	}
+
+	return compiled
+}
+
+// String returns the path as it was written
+func (p *Path) String() string {
+	return p.raw
+}
+
+// segment is a single step of the path: a field name, a map key or an index
+type segment struct {
+	name    string                       // name is the segment with quotes and escapes removed
+	literal bool                         // literal is set when the segment was quoted or escaped in the path
+	cache   *atomic.Pointer[resolutions] // cache holds the resolutions of the segment made for the types met
+	filter  *filter                      // filter selects the elements of a collection, e.g. [?ID==42]
+}
+
+// wildcard reports whether the segment is the wildcard "*" matching all the elements of a collection,
+// a quoted or escaped asterisk is a plain map key
+func (s segment) wildcard() bool {
+	return s.name == "*" && !s.literal
+}
+
+// descent reports whether the segment is the recursive descent "**" matching any number of levels of the path
+func (s segment) descent() bool {
+	return s.name == descentToken && !s.literal
+}
+
+// parsePath splits the path into segments. Besides the plain dotted syntax
+// it supports bracketed segments and escaped dots, so that map keys may contain dots,
+// and filters selecting the elements of a collection:
+//
+//	Hosts["example.com"].Port, Hosts['example.com'].Port, Items[3], Versions.v1\.2, Users[?ID==42]
+func parsePath(path string) ([]segment, error) {
+	segments := []segment{}
+	if path == "" {
+		return segments, nil
+	}
+
+	position := 0
+	for {
+		// A segment is either plain or written in brackets
+		if position < len(path) && path[position] == '[' {
+			part, next, err := parseBracket(path, position)
+			if err != nil {
+				return nil, err
+			}
+
+			segments, position = append(segments, part), next
+		} else {
+			part, next, err := parsePlain(path, position)
+			if err != nil {
+				return nil, err
+			}
+
+			segments, position = append(segments, part), next
+		}
+
+		// Any number of bracketed segments can follow without a dot, e.g. Matrix[1][2]
+		for position < len(path) && path[position] == '[' {
+			part, next, err := parseBracket(path, position)
+			if err != nil {
+				return nil, err
+			}
+
+			segments, position = append(segments, part), next
+		}
+
+		// The recursive descent already matches any number of levels
+		if count := len(segments); count > 1 && segments[count-1].descent() && segments[count-2].descent() {
+			return nil, pathError(path, position, "the recursive descent cannot follow the recursive descent")
+		}
+
+		if position == len(path) {
+			// The recursive descent needs a segment to look for
+			if last := segments[len(segments)-1]; last.descent() {
+				return nil, pathError(path, len(path), "the recursive descent must be followed by a segment")
+			}
+
+			return segments, nil
+		}
+
+		if path[position] != '.' {
+			return nil, pathError(path, position, fmt.Sprintf("unexpected character %q", path[position]))
+		}
+
+		// Skipping the dot separating the segments
+		position++
+	}
+}
+
+// parsePlain reads the segment up to the next dot or bracket, a backslash escapes the following character
+func parsePlain(path string, position int) (segment, int, error) {
+	var (
+		name    strings.Builder
+		literal bool
+	)
+
+	for ; position < len(path); position++ {
+		switch path[position] {
+		case '.', '[':
+			return segment{name: name.String(), literal: literal}, position, nil
+		case '\\':
+			if position+1 == len(path) {
+				return segment{}, 0, pathError(path, position, "nothing to escape")
+			}
+
+			position++
+			literal = true
+		}
+
+		name.WriteByte(path[position])
+	}
+
+	return segment{name: name.String(), literal: literal}, position, nil
+}
+
+// parseBracket reads the segment written in brackets, either quoted or as is, e.g. ["example.com"] or [3]
+func parseBracket(path string, position int) (segment, int, error) {
+	start := position
+	position++
+
+	if position < len(path) && path[position] == '?' {
+		return parseFilter(path, start, position)
+	}
+
+	// The contents of the brackets are taken as is up to the closing bracket
+	if position == len(path) || (path[position] != '"' && path[position] != '\'') {
+		end := strings.IndexByte(path[position:], ']')
+		if end == -1 {
+			return segment{}, 0, pathError(path, start, "missing closing bracket")
+		}
+
+		return segment{name: path[position : position+end]}, position + end + 1, nil
+	}
+
+	quote := path[position]
+	position++
+
+	var name strings.Builder
+	for ; position < len(path); position++ {
+		switch path[position] {
+		case quote:
+			if position+1 == len(path) || path[position+1] != ']' {
+				return segment{}, 0, pathError(path, position+1, "missing closing bracket")
+			}
+
+			return segment{name: name.String(), literal: true}, position + 2, nil
+		case '\\':
+			if position+1 < len(path) {
+				position++
+			}
+		}
+
+		name.WriteByte(path[position])
+	}
+
+	return segment{}, 0, pathError(path, start, "unterminated quoted key")
+}
+
+// reserved reports whether the name has a special meaning in the path unless it is quoted
+func reserved(name string) bool {
+	return name == "*" || name == descentToken || name == appendToken
+}
+
+// pathError describes the syntax error found in the path
+func pathError(path string, position int, reason string) error {
+	return &SyntaxError{Path: path, Position: position, Reason: reason}
+}
+
+// preparePath joins the segments back into a path
+// e.g. ["old", "example.com", "path"] => `old["example.com"].path`
+func preparePath(parts []segment) string {
+	var path strings.Builder
+
+	for index, part := range parts {
+		if part.filter != nil {
+			path.WriteString("[" + part.name + "]")
+			continue
+		}
+
+		// Segments that cannot be written plainly are written in brackets,
+		// as are the keys that would be read back as the wildcard, the recursive descent or the append token
+		if strings.ContainsAny(part.name, `.[]\"'`) || (part.literal && reserved(part.name)) {
+			path.WriteString(`["`)
+			path.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(part.name))
+			path.WriteString(`"]`)
+
+			continue
+		}
+
+		if index > 0 {
+			path.WriteByte('.')
+		}
+
+		path.WriteString(part.name)
+	}
+
+	return path.String()
+}
+
+// trail is the part of the path traversed so far. It is formatted only
+// when an error is reported, so that a successful walk does not build strings
+type trail struct {
+	segments []segment // segments is the whole path
+	length   int       // length is the number of segments traversed
+}
+
+// next returns the trail extended by the number of segments
+func (t trail) next(count int) trail {
+	t.length += count
+	return t
+}
+
+// with returns the trail extended by the segment, in which the wildcard is replaced by the concrete name
+func (t trail) with(name string) trail {
+	segments := make([]segment, len(t.segments))
+	copy(segments, t.segments)
+	segments[t.length] = segment{name: name, literal: true}
+
+	return trail{segments: segments, length: t.length + 1}
+}
+
+// skip returns the trail without the segment following the traversed part,
+// so that the recursive descent can be replaced by any number of concrete segments
+func (t trail) skip() trail {
+	segments := make([]segment, 0, len(t.segments)-1)
+	segments = append(append(segments, t.segments[:t.length]...), t.segments[t.length+1:]...)
+
+	return trail{segments: segments, length: t.length}
+}
+
+// descend returns the trail extended by the concrete segment inserted before the rest of the path
+func (t trail) descend(name string) trail {
+	segments := make([]segment, 0, len(t.segments)+1)
+	segments = append(append(segments, t.segments[:t.length]...), segment{name: name, literal: true})
+	segments = append(segments, t.segments[t.length:]...)
+
+	return trail{segments: segments, length: t.length + 1}
+}
+
+// String formats the traversed part of the path
+func (t trail) String() string {
+	return preparePath(t.segments[:t.length])
+}
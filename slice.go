@@ -1,17 +1,51 @@
 package dot
 
 import (
-	"fmt"
+	"context"
 	"reflect"
 	"strconv"
 )
 
-// inSlice inserts a value into a slice along the specified path
-func (d *Dot) inSlice(innerObj reflect.Value, currentPath string, parts []string) error {
-	// Getting the slice index
-	index, err := strconv.Atoi(parts[0])
+// appendToken is the slice index that always appends the value to the slice,
+// the same as index -1 does in Insert
+const appendToken = "+"
+
+// InsertAt inserts the value into the slice at the index ending the path and shifts the following
+// elements to the right, e.g. InsertAt("Rules.2", rule). Negative indexes are counted from the end,
+// and the index equal to the length of the slice or the append token "+" adds the value at the end
+func (d *Dot) InsertAt(path string, content any) error {
+	compiled, err := compile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(compiled.segments) == 0 {
+		return &InvalidIndexError{Path: path, Kind: reflect.Slice}
+	}
+
+	return d.apply(&insertion{ctx: context.Background(), shift: true}, compiled.segments, content)
+}
+
+// Prepend inserts the value at the beginning of the slice located at the path
+func (d *Dot) Prepend(path string, content any) error {
+	compiled, err := compile(path)
 	if err != nil {
-		return fmt.Errorf(`invalid value "%s" as a slice index`, parts[0])
+		return err
+	}
+
+	// The compiled segments are shared, so the index is added to a copy of them
+	segments := append(compiled.segments[:len(compiled.segments):len(compiled.segments)], segment{name: "0"})
+
+	return d.apply(&insertion{ctx: context.Background(), shift: true}, segments, content)
+}
+
+// inSlice inserts a value into a slice along the specified path
+func (d *Dot) inSlice(op *insertion, innerObj reflect.Value, currentPath trail, parts []segment, content any) error {
+	name := parts[0].name
+
+	// Only a valid index can create a new slice
+	if _, err := strconv.Atoi(name); err != nil && name != appendToken {
+		return &InvalidIndexError{Path: currentPath.String(), Value: name, Kind: reflect.Slice}
 	}
 
 	// Initialising a new slice
@@ -19,28 +53,34 @@ func (d *Dot) inSlice(innerObj reflect.Value, currentPath string, parts []string
 		innerObj.Set(reflect.MakeSlice(innerObj.Type(), 0, 0))
 	}
 
-	// If the index is greater than -1, it means that the value
-	// at the specified index must be replaced
-	if index > -1 {
-		if innerObj.Len() <= index {
-			return fmt.Errorf("index %d out of range in path %s", index, currentPath)
-		}
+	switch {
+	case op.shift && len(parts) == 1:
+		// InsertAt and Prepend shift the following elements instead of replacing the one at the index
+		return d.insertValue(op, innerObj, currentPath, name, content)
+	case name == appendToken || name == "-1":
+		// Index -1 and the append token mean that the value should be inserted at the end of the slice
+		return d.appendValue(op, innerObj, currentPath, parts[1:], content)
+	}
 
-		// Accessing the value of this index
-		innerObj = innerObj.Index(index)
+	// In the grow mode the slice is extended with zero values up to the index
+	if index, _ := strconv.Atoi(name); d.Grow && index >= innerObj.Len() && index < d.GrowLimit {
+		return d.growValue(op, innerObj, currentPath, parts, index, content)
+	}
 
-		// Insert a value along the specified path
-		return d.insert(innerObj, currentPath, parts[1:], Slice)
+	// The value at the specified index must be replaced
+	index, err := elementIndex(innerObj, name, currentPath)
+	if err != nil {
+		return err
 	}
 
-	// Index -1 means that the value should be inserted at the end of the slice
-	return d.appendValue(innerObj, currentPath, parts[1:])
+	// Insert a value along the specified path
+	return d.insert(op, innerObj.Index(index), currentPath, parts[1:], content, Slice)
 }
 
-func (d *Dot) appendValue(innerObj reflect.Value, currentPath string, remainingParts []string) error {
+func (d *Dot) appendValue(op *insertion, innerObj reflect.Value, currentPath trail, remainingParts []segment, content any) error {
 	value := reflect.New(innerObj.Type().Elem()).Elem()
 
-	if err := d.insert(value, currentPath, remainingParts, Slice); err != nil {
+	if err := d.insert(op, value, currentPath, remainingParts, content, Slice); err != nil {
 		return err
 	}
 
@@ -48,3 +88,65 @@ func (d *Dot) appendValue(innerObj reflect.Value, currentPath string, remainingP
 
 	return nil
 }
+
+// growValue extends the slice with zero values, so that the index becomes the last one, and inserts the value
+// at the index. The slice is extended only if the insertion succeeds
+func (d *Dot) growValue(
+	op *insertion, innerObj reflect.Value, currentPath trail, parts []segment, index int, content any,
+) error {
+	missing := index + 1 - innerObj.Len()
+	grown := reflect.AppendSlice(innerObj, reflect.MakeSlice(innerObj.Type(), missing, missing))
+
+	if err := d.insert(op, grown.Index(index), currentPath, parts[1:], content, Slice); err != nil {
+		return err
+	}
+
+	innerObj.Set(grown)
+
+	return nil
+}
+
+// insertValue inserts the value at the position in the slice and shifts the following elements to the right
+func (d *Dot) insertValue(op *insertion, innerObj reflect.Value, currentPath trail, name string, content any) error {
+	position, err := insertionIndex(innerObj, name, currentPath)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.New(innerObj.Type().Elem()).Elem()
+	if err := d.insert(op, value, currentPath, nil, content, Slice); err != nil {
+		return err
+	}
+
+	length := innerObj.Len()
+	innerObj.Set(reflect.Append(innerObj, reflect.Zero(value.Type())))
+	reflect.Copy(innerObj.Slice(position+1, length+1), innerObj.Slice(position, length))
+	innerObj.Index(position).Set(value)
+
+	return nil
+}
+
+// insertionIndex converts the segment into the position of a new element, from 0 up to the length
+// of the slice. Negative positions are counted from the end, the append token is the length itself
+func insertionIndex(innerObj reflect.Value, value string, currentPath trail) (int, error) {
+	length := innerObj.Len()
+	if value == appendToken {
+		return length, nil
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &InvalidIndexError{Path: currentPath.String(), Value: value, Kind: reflect.Slice}
+	}
+
+	position := index
+	if position < 0 {
+		position += length
+	}
+
+	if position < 0 || position > length {
+		return 0, outOfRange(innerObj, index, currentPath)
+	}
+
+	return position, nil
+}
@@ -0,0 +1,215 @@
+package dot
+
+import (
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// tagKey is the struct tag of the package, it takes precedence over any other tag
+const tagKey = "dot"
+
+// field returns the struct field matching the path segment. The embedded structs on the way
+// to a promoted field are allocated if they are nil pointers, unless allocate is false,
+// in which case such a field is reported as an unknown path
+func (d *Dot) field(innerObj reflect.Value, part segment, currentPath trail, allocate bool) (reflect.Value, error) {
+	structType := innerObj.Type()
+
+	// The field has already been looked up for this type
+	cached := part.cached(structType)
+	if cached == nil || cached.tagKey != d.TagKey {
+		index, candidates := d.fieldIndex(structType, part.name)
+		cached = &resolved{typ: structType, tagKey: d.TagKey, field: index, candidates: candidates}
+		part.remember(cached)
+	}
+
+	if len(cached.candidates) > 1 {
+		return reflect.Value{}, &AmbiguousFieldError{
+			Path:       currentPath.String(),
+			Name:       part.name,
+			Candidates: cached.candidates,
+		}
+	}
+
+	if cached.field == nil {
+		return reflect.Value{}, unknownPath(currentPath)
+	}
+
+	// Fields of the values that cannot be addressed, e.g. stored in maps, can be exposed only in a copy
+	if d.Unsafe && !innerObj.CanAddr() {
+		copied := reflect.New(structType).Elem()
+		copied.Set(innerObj)
+		innerObj = copied
+	}
+
+	return d.fieldByIndex(innerObj, cached.field, currentPath, allocate)
+}
+
+// fieldByIndex returns the nested field by its index, following the embedded pointers on the way
+func (d *Dot) fieldByIndex(innerObj reflect.Value, index []int, currentPath trail, allocate bool) (reflect.Value, error) {
+	for position, fieldIndex := range index {
+		if position > 0 && innerObj.Kind() == reflect.Pointer {
+			if innerObj.IsNil() {
+				if !allocate {
+					return reflect.Value{}, unknownPath(currentPath)
+				}
+
+				innerObj.Set(reflect.New(innerObj.Type().Elem()))
+			}
+
+			innerObj = innerObj.Elem()
+		}
+
+		structType := innerObj.Type()
+		innerObj = innerObj.Field(fieldIndex)
+
+		// The exported fields promoted from the unexported embedded structs are accessible,
+		// but a nil pointer to such a struct cannot be allocated
+		blocked := innerObj.Kind() == reflect.Pointer && innerObj.IsNil() && allocate && !innerObj.CanSet()
+		if position == len(index)-1 {
+			blocked = !innerObj.CanInterface()
+		}
+
+		if !blocked {
+			continue
+		}
+
+		if !d.Unsafe || !innerObj.CanAddr() {
+			return reflect.Value{}, &UnexportedFieldError{
+				Path:  currentPath.String(),
+				Field: structType.Field(fieldIndex).Name,
+				Type:  structType,
+			}
+		}
+
+		innerObj = exposed(innerObj)
+	}
+
+	return innerObj, nil
+}
+
+// exposed returns the unexported field as a value that can be read and set, it is used only in the unsafe mode
+func exposed(field reflect.Value) reflect.Value {
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+// fieldIndex finds the field by the name from its tag and then by the name of the field itself.
+// Following the rules of Go, the field declared at the shallowest depth of the embedded structs is chosen,
+// and if there are several of them, the name is ambiguous and the paths to all of them are returned
+func (d *Dot) fieldIndex(structType reflect.Type, name string) ([]int, []string) {
+	fields := findFields(structType, func(field reflect.StructField) bool {
+		alias, _ := d.tagName(field)
+		return alias != "" && alias == name
+	})
+
+	if len(fields) == 0 {
+		fields = findFields(structType, func(field reflect.StructField) bool {
+			return field.Name == name
+		})
+	}
+
+	switch {
+	case len(fields) == 0:
+		return nil, nil
+	case len(fields) > 1:
+		candidates := make([]string, 0, len(fields))
+		for _, field := range fields {
+			candidates = append(candidates, field.path)
+		}
+
+		return nil, candidates
+	}
+
+	// Fields tagged with "-" cannot be reached at all
+	if _, hidden := d.tagName(fields[0].StructField); hidden {
+		return nil, nil
+	}
+
+	return fields[0].Index, nil
+}
+
+// embeddedField is the field found in the struct or in one of its embedded structs
+type embeddedField struct {
+	reflect.StructField
+	path string // path is the path to the field through the embedded structs, e.g. Base.ID
+}
+
+// findFields returns the matching fields declared at the shallowest depth of the embedded structs
+func findFields(structType reflect.Type, match func(reflect.StructField) bool) []embeddedField {
+	current := []embeddedField{{StructField: reflect.StructField{Type: structType}}}
+	visited := map[reflect.Type]bool{}
+
+	for len(current) > 0 {
+		var found, next []embeddedField
+
+		// The struct embedded at a shallower depth hides the same struct embedded deeper,
+		// while the one embedded twice at the same depth makes its fields ambiguous
+		seen := map[reflect.Type]bool{}
+
+		for _, embedded := range current {
+			embeddedType := embedded.Type
+			if embeddedType.Kind() == reflect.Pointer {
+				embeddedType = embeddedType.Elem()
+			}
+
+			if visited[embeddedType] {
+				continue
+			}
+
+			seen[embeddedType] = true
+
+			for position := 0; position < embeddedType.NumField(); position++ {
+				field := embeddedField{StructField: embeddedType.Field(position)}
+				field.Index = append(append([]int{}, embedded.Index...), position)
+				field.path = field.Name
+				if embedded.path != "" {
+					field.path = embedded.path + "." + field.Name
+				}
+
+				if match(field.StructField) {
+					found = append(found, field)
+					continue
+				}
+
+				// The fields of the embedded structs are searched at the next depth
+				if field.Anonymous && baseKind(field.Type) == reflect.Struct {
+					next = append(next, field)
+				}
+			}
+		}
+
+		if len(found) > 0 {
+			return found
+		}
+
+		for embeddedType := range seen {
+			visited[embeddedType] = true
+		}
+
+		current = next
+	}
+
+	return nil
+}
+
+// tagName returns the name of the field from the "dot" tag or, if there is none,
+// from the tag chosen in TagKey, ignoring options after the comma.
+// e.g. `json:"user_name,omitempty"` => "user_name"
+func (d *Dot) tagName(field reflect.StructField) (name string, hidden bool) {
+	for _, key := range []string{tagKey, d.TagKey} {
+		if key == "" {
+			continue
+		}
+
+		if value, ok := field.Tag.Lookup(key); ok {
+			if value == "-" {
+				return "", true
+			}
+
+			name, _, _ = strings.Cut(value, ",")
+			return name, false
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,101 @@
+package dot
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// text is the string inserted with InsertString,
+// it is parsed into the type found at the end of the path
+type text string
+
+// textUnmarshaler is the reflection type of encoding.TextUnmarshaler
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// textMarshaler and stringer are the reflection types of the interfaces writing the map keys in the path
+var (
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringer      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// defaultParsers returns the parsers of the types that cannot unmarshal text themselves
+func defaultParsers() map[reflect.Type]func(string) (any, error) {
+	return map[reflect.Type]func(string) (any, error){
+		reflect.TypeOf(time.Duration(0)): func(value string) (any, error) {
+			return time.ParseDuration(value)
+		},
+	}
+}
+
+// InsertString parses the string into the type found at the end of the path and inserts it.
+// The string is parsed by the encoding.TextUnmarshaler of the type, then by the parser registered
+// for the type with RegisterParser and finally as a primitive type, e.g. int, float64 or bool
+func (d *Dot) InsertString(path string, value string) error {
+	compiled, err := compile(path)
+	if err != nil {
+		return err
+	}
+
+	return d.InsertPath(compiled, text(value))
+}
+
+// RegisterParser registers the function that parses strings inserted with InsertString
+// into values of the type, e.g. RegisterParser(reflect.TypeOf(uuid.UUID{}), parseUUID)
+func (d *Dot) RegisterParser(typ reflect.Type, parser func(string) (any, error)) {
+	d.lock()
+	defer d.unlock()
+
+	d.Parsers[typ] = parser
+}
+
+// parseText converts the string into a value of the type in the path
+func (d *Dot) parseText(target reflect.Type, value string, currentPath trail) (reflect.Value, error) {
+	// Values of pointer types are parsed into the values they refer to
+	base := target
+	if base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	parsed, ok, err := d.decode(base, value)
+	if err != nil {
+		return reflect.Value{}, &ParseError{Path: currentPath.String(), Value: value, Type: target, Err: err}
+	}
+
+	if ok {
+		return parsed, nil
+	}
+
+	// Primitive types are parsed the same way as map keys
+	if _, err := parseType(base.Kind(), value); err != nil && !errors.Is(err, errUnknownType) {
+		return reflect.Value{}, &ParseError{Path: currentPath.String(), Value: value, Type: target, Err: err}
+	}
+
+	return convertValue(reflect.ValueOf(value), target), nil
+}
+
+// decode parses the string by the encoding.TextUnmarshaler of the type or by the parser
+// registered for the type, ok is false if the type has neither of them
+func (d *Dot) decode(typ reflect.Type, value string) (reflect.Value, bool, error) {
+	if reflect.PointerTo(typ).Implements(textUnmarshaler) {
+		parsed := reflect.New(typ)
+		if err := parsed.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return reflect.Value{}, true, err
+		}
+
+		return parsed.Elem(), true, nil
+	}
+
+	if parser, ok := d.Parsers[typ]; ok {
+		parsed, err := parser(value)
+		if err != nil {
+			return reflect.Value{}, true, err
+		}
+
+		return reflect.ValueOf(parsed), true, nil
+	}
+
+	return reflect.Value{}, false, nil
+}
@@ -0,0 +1,38 @@
+package dot
+
+import "reflect"
+
+// GetAs returns the value located at the specified path as a value of type T.
+// If the value cannot be represented as T, the zero value of T and a *TypeMismatchError are returned
+func GetAs[T any](d *Dot, path string) (T, error) {
+	var zero T
+
+	value, err := d.Get(path)
+	if err != nil {
+		return zero, err
+	}
+
+	if result, ok := value.(T); ok {
+		return result, nil
+	}
+
+	expected := reflect.TypeOf(&zero).Elem()
+
+	// An empty interface can be returned as any other interface type
+	if value == nil && expected.Kind() == reflect.Interface {
+		return zero, nil
+	}
+
+	return zero, &TypeMismatchError{
+		Path:     path,
+		Expected: expected,
+		Got:      reflect.TypeOf(value),
+		Scenario: Var,
+	}
+}
+
+// InsertAs inserts the value of type T into the specified path.
+// The compiler checks the type of the value, so only the type stored in the path is checked at runtime
+func InsertAs[T any](d *Dot, path string, value T) error {
+	return d.Insert(path, value)
+}
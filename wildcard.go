@@ -0,0 +1,217 @@
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Match is a value found by the path with wildcards
+type Match struct {
+	Path  string // Path is the concrete path to the value, with wildcards replaced by indexes and keys
+	Value any    // Value is the value found at the path
+}
+
+// GetAll returns all the values matching the path, in which the wildcard "*" matches every element
+// of a slice or an array and every key of a map, e.g. Features.*.Enabled, and the recursive descent "**"
+// matches any number of levels, e.g. **.Password. Slice and array elements are returned in order,
+// map keys are sorted. If the path cannot be followed for some of the elements,
+// the values found for the others are returned together with *PartialError
+func (d *Dot) GetAll(path string) ([]Match, error) {
+	compiled, err := compile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	d.readLock()
+	defer d.readUnlock()
+
+	var (
+		matches  []Match
+		failures []error
+	)
+
+	if err := d.getAll(d.Object, trail{segments: compiled.segments}, compiled.segments, &matches, &failures); err != nil {
+		return nil, err
+	}
+
+	if len(failures) > 0 {
+		return matches, &PartialError{Path: path, Errors: failures}
+	}
+
+	return matches, nil
+}
+
+// getAll walks along the path up to the next wildcard or filter and continues from each of the elements matched by it.
+// The errors of the path before the first wildcard are returned, the errors of the elements are collected
+func (d *Dot) getAll(
+	innerObj reflect.Value, previousPath trail, parts []segment, matches *[]Match, failures *[]error,
+) error {
+	next := 0
+	for next < len(parts) && !parts[next].wildcard() && !parts[next].descent() && parts[next].filter == nil {
+		next++
+	}
+
+	value, err := d.get(innerObj, previousPath, parts[:next])
+	if err != nil {
+		return err
+	}
+
+	currentPath := previousPath.next(next)
+	if next == len(parts) {
+		*matches = append(*matches, Match{Path: currentPath.String(), Value: value.Interface()})
+		return nil
+	}
+
+	if parts[next].descent() {
+		d.getDescent(value, currentPath.skip(), parts[next+1:], matches, failures, newVisits())
+		return nil
+	}
+
+	for (value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer) && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if !collection(value.Kind()) {
+		return unknownPath(currentPath.next(1))
+	}
+
+	found, err := d.selected(value, parts[next], currentPath.next(1))
+	if err != nil {
+		return err
+	}
+
+	for _, element := range found {
+		err := d.getAll(element.value, currentPath.with(element.name), parts[next+1:], matches, failures)
+		if err != nil {
+			*failures = append(*failures, err)
+		}
+	}
+
+	return nil
+}
+
+// insertEach inserts the value along the rest of the path into every element of the collection
+// matched by the wildcard or the filter.
+// The value is inserted into as many elements as possible, and the errors are reported per element
+func (d *Dot) insertEach(
+	op *insertion, innerObj reflect.Value, previousPath trail, parts []segment, content any, source Scenario,
+) error {
+	found, err := d.selected(innerObj, parts[0], previousPath.next(1))
+	if err != nil {
+		return err
+	}
+
+	// The filter addresses the elements, so it fails if there is none to change
+	if parts[0].filter != nil && len(found) == 0 {
+		return unknownPath(previousPath.next(1))
+	}
+
+	var failures []error
+
+	for _, element := range found {
+		currentPath := previousPath.with(element.name)
+
+		// Elements of maps and arrays are changed in a copy which is written back,
+		// so that a failed insertion leaves them untouched
+		value := element.value
+		if innerObj.Kind() != reflect.Slice {
+			value = reflect.New(element.value.Type()).Elem()
+			value.Set(element.value)
+		}
+
+		if err := d.insert(op, value, currentPath, parts[1:], content, source); err != nil {
+			// The failures of the nested wildcards are reported as the failures of the elements
+			var partial *PartialError
+			if errors.As(err, &partial) {
+				failures = append(failures, partial.Errors...)
+			} else {
+				failures = append(failures, err)
+			}
+
+			continue
+		}
+
+		switch innerObj.Kind() {
+		case reflect.Map:
+			innerObj.SetMapIndex(element.key, value)
+		case reflect.Array:
+			element.value.Set(value)
+		}
+	}
+
+	if len(failures) > 0 {
+		return &PartialError{Path: previousPath.next(len(parts)).String(), Errors: failures}
+	}
+
+	return nil
+}
+
+// several reports the first segment of the path that can match several values, which is not supported
+// by the methods working with a single value. The filter is reported only if filters is set
+func several(segments []segment, filters bool) error {
+	for index, part := range segments {
+		if part.wildcard() || part.descent() || (filters && part.filter != nil) {
+			return &PathError{
+				Path:    preparePath(segments[:index+1]),
+				Segment: part.name,
+				Index:   index,
+				Err:     ErrWildcard,
+			}
+		}
+	}
+
+	return nil
+}
+
+// element is a single element of a collection matched by the wildcard
+type element struct {
+	name  string        // name is the index or the key written in the path
+	key   reflect.Value // key is the map key, if the collection is a map
+	value reflect.Value // value is the element itself
+}
+
+// elements returns the elements of the slice, the array or the map, the keys of the map are sorted
+// and named in the way they are written in the path
+func (d *Dot) elements(innerObj reflect.Value) []element {
+	if innerObj.Kind() != reflect.Map {
+		result := make([]element, innerObj.Len())
+		for index := range result {
+			result[index] = element{name: fmt.Sprint(index), value: innerObj.Index(index)}
+		}
+
+		return result
+	}
+
+	keys := innerObj.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+
+	result := make([]element, len(keys))
+	for index, key := range keys {
+		result[index] = element{name: d.formatKey(key), key: key, value: innerObj.MapIndex(key)}
+	}
+
+	return result
+}
+
+// lessKey orders the map keys, numbers by their value and anything else by its text
+func lessKey(a, b reflect.Value) bool {
+	switch {
+	case isInt(a.Kind()):
+		return a.Int() < b.Int()
+	case isUint(a.Kind()):
+		return a.Uint() < b.Uint()
+	case isFloat(a.Kind()):
+		return a.Float() < b.Float()
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+// collection reports whether the wildcard can match the elements of the value of this kind
+func collection(kind reflect.Kind) bool {
+	return kind == reflect.Map || kind == reflect.Slice || kind == reflect.Array
+}